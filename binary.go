@@ -0,0 +1,221 @@
+package option
+
+import (
+  "bytes"
+  "encoding"
+  "encoding/binary"
+  "encoding/gob"
+  "errors"
+  "fmt"
+  "math"
+  "reflect"
+)
+
+var (
+  ErrInvalidBinary = errors.New("option: invalid binary encoding")
+)
+
+// binaryVersion is the version of the binary format written by AppendBinary.
+//
+// The format is a version byte, a flags byte and a payload. For Some options
+// the payload is the contained value; for None options it is the error
+// message, if the option carries an error. Values are encoded with their own
+// MarshalBinary method when they have one, compactly for strings, booleans and
+// numeric kinds (varints for integers, IEEE 754 bits for floats), and with
+// encoding/gob otherwise.
+const binaryVersion = 1
+
+const (
+  binarySome byte = 1 << iota
+  binaryError
+)
+
+// AppendBinary implements encoding.BinaryAppender. It appends the versioned
+// binary encoding of the option to b, keeping presence, the contained value
+// and, for None options, the error message.
+func (o Option[T]) AppendBinary(b []byte) ([]byte, error) {
+  switch {
+  case o.ok:
+    b = append(b, binaryVersion, binarySome)
+    return appendBinaryValue(b, reflect.ValueOf(&o.some).Elem())
+  case o.err != nil:
+    b = append(b, binaryVersion, binaryError)
+    return append(b, o.err.Error()...), nil
+  default:
+    return append(b, binaryVersion, 0), nil
+  }
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler. See AppendBinary for a
+// description of the format.
+//
+// Example:
+//
+//	data, _ := option.Some(42).MarshalBinary()
+//
+//	var opt option.Option[int]
+//	_ = opt.UnmarshalBinary(data) // Some(42)
+func (o Option[T]) MarshalBinary() ([]byte, error) {
+  return o.AppendBinary(nil)
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler. A None option decoded
+// from data that carried an error message holds the error registered for that
+// message with RegisterError, or an opaque error with the same message. On
+// error the option is left unchanged.
+func (o *Option[T]) UnmarshalBinary(data []byte) error {
+  if len(data) < 2 {
+    return fmt.Errorf("%w: short header", ErrInvalidBinary)
+  }
+  if data[0] != binaryVersion {
+    return fmt.Errorf("%w: unknown version %d", ErrInvalidBinary, data[0])
+  }
+
+  flags, payload := data[1], data[2:]
+  switch flags {
+  case binarySome:
+    var value T
+    if err := decodeBinaryValue(payload, reflect.ValueOf(&value).Elem()); err != nil {
+      return err
+    }
+    // The flags already record presence. Decoding may turn empty slices and
+    // maps into nil ones, which Some would report as ErrNilValue.
+    *o = Option[T]{ok: true, some: value}
+  case binaryError:
+    *o = None[T](decodeError(string(payload)))
+  case 0:
+    if len(payload) != 0 {
+      return fmt.Errorf("%w: unexpected payload", ErrInvalidBinary)
+    }
+    *o = None[T](nil)
+  default:
+    return fmt.Errorf("%w: unknown flags %#x", ErrInvalidBinary, flags)
+  }
+  return nil
+}
+
+// GobEncode implements gob.GobEncoder, so options keep their state when they
+// are gob-encoded despite having no exported fields. It uses the same format
+// as MarshalBinary.
+func (o Option[T]) GobEncode() ([]byte, error) {
+  return o.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder. It uses the same format as
+// UnmarshalBinary.
+func (o *Option[T]) GobDecode(data []byte) error {
+  return o.UnmarshalBinary(data)
+}
+
+// appendBinaryValue appends the binary encoding of v to b.
+func appendBinaryValue(b []byte, v reflect.Value) ([]byte, error) {
+  switch m := v.Addr().Interface().(type) {
+  case encoding.BinaryAppender:
+    return m.AppendBinary(b)
+  case encoding.BinaryMarshaler:
+    data, err := m.MarshalBinary()
+    if err != nil {
+      return nil, err
+    }
+    return append(b, data...), nil
+  }
+
+  switch v.Kind() {
+  case reflect.String:
+    return append(b, v.String()...), nil
+  case reflect.Bool:
+    if v.Bool() {
+      return append(b, 1), nil
+    }
+    return append(b, 0), nil
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    return binary.AppendVarint(b, v.Int()), nil
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    return binary.AppendUvarint(b, v.Uint()), nil
+  case reflect.Float32:
+    return binary.BigEndian.AppendUint32(b, math.Float32bits(float32(v.Float()))), nil
+  case reflect.Float64:
+    return binary.BigEndian.AppendUint64(b, math.Float64bits(v.Float())), nil
+  case reflect.Complex64:
+    c := v.Complex()
+    b = binary.BigEndian.AppendUint32(b, math.Float32bits(float32(real(c))))
+    return binary.BigEndian.AppendUint32(b, math.Float32bits(float32(imag(c)))), nil
+  case reflect.Complex128:
+    c := v.Complex()
+    b = binary.BigEndian.AppendUint64(b, math.Float64bits(real(c)))
+    return binary.BigEndian.AppendUint64(b, math.Float64bits(imag(c))), nil
+  }
+
+  buf := bytes.NewBuffer(b)
+  if err := gob.NewEncoder(buf).EncodeValue(v); err != nil {
+    return nil, err
+  }
+  return buf.Bytes(), nil
+}
+
+// decodeBinaryValue decodes data, as written by appendBinaryValue, into the
+// settable value v.
+func decodeBinaryValue(data []byte, v reflect.Value) error {
+  if u, ok := v.Addr().Interface().(encoding.BinaryUnmarshaler); ok {
+    return u.UnmarshalBinary(data)
+  }
+
+  switch v.Kind() {
+  case reflect.String:
+    v.SetString(string(data))
+    return nil
+  case reflect.Bool:
+    if len(data) != 1 || data[0] > 1 {
+      return fmt.Errorf("%w: invalid %s", ErrInvalidBinary, v.Type())
+    }
+    v.SetBool(data[0] == 1)
+    return nil
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    n, size := binary.Varint(data)
+    if size <= 0 || size != len(data) || v.OverflowInt(n) {
+      return fmt.Errorf("%w: invalid %s", ErrInvalidBinary, v.Type())
+    }
+    v.SetInt(n)
+    return nil
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    n, size := binary.Uvarint(data)
+    if size <= 0 || size != len(data) || v.OverflowUint(n) {
+      return fmt.Errorf("%w: invalid %s", ErrInvalidBinary, v.Type())
+    }
+    v.SetUint(n)
+    return nil
+  case reflect.Float32:
+    if len(data) != 4 {
+      return fmt.Errorf("%w: invalid %s", ErrInvalidBinary, v.Type())
+    }
+    v.SetFloat(float64(math.Float32frombits(binary.BigEndian.Uint32(data))))
+    return nil
+  case reflect.Float64:
+    if len(data) != 8 {
+      return fmt.Errorf("%w: invalid %s", ErrInvalidBinary, v.Type())
+    }
+    v.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(data)))
+    return nil
+  case reflect.Complex64:
+    if len(data) != 8 {
+      return fmt.Errorf("%w: invalid %s", ErrInvalidBinary, v.Type())
+    }
+    re := math.Float32frombits(binary.BigEndian.Uint32(data))
+    im := math.Float32frombits(binary.BigEndian.Uint32(data[4:]))
+    v.SetComplex(complex(float64(re), float64(im)))
+    return nil
+  case reflect.Complex128:
+    if len(data) != 16 {
+      return fmt.Errorf("%w: invalid %s", ErrInvalidBinary, v.Type())
+    }
+    re := math.Float64frombits(binary.BigEndian.Uint64(data))
+    im := math.Float64frombits(binary.BigEndian.Uint64(data[8:]))
+    v.SetComplex(complex(re, im))
+    return nil
+  }
+
+  if err := gob.NewDecoder(bytes.NewReader(data)).DecodeValue(v); err != nil {
+    return fmt.Errorf("%w: %w", ErrInvalidBinary, err)
+  }
+  return nil
+}
@@ -0,0 +1,229 @@
+package option
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type binaryRecord struct {
+	Name  string
+	Email Option[string]
+	Age   Option[int]
+	Tags  Option[[]string]
+	Seen  Option[time.Time]
+}
+
+func roundTripBinary[T any](t *testing.T, in Option[T]) Option[T] {
+	t.Helper()
+	data, err := in.MarshalBinary()
+	require.NoError(t, err)
+
+	var out Option[T]
+	require.NoError(t, out.UnmarshalBinary(data))
+	return out
+}
+
+func TestOption_MarshalBinary_RoundTrip(t *testing.T) {
+	assert.Equal(t, "hello", roundTripBinary(t, Some("hello")).Unwrap())
+	assert.Equal(t, "", roundTripBinary(t, Some("")).Unwrap())
+	assert.Equal(t, -42, roundTripBinary(t, Some(-42)).Unwrap())
+	assert.Equal(t, int8(math.MinInt8), roundTripBinary(t, Some(int8(math.MinInt8))).Unwrap())
+	assert.Equal(t, uint64(math.MaxUint64), roundTripBinary(t, Some(uint64(math.MaxUint64))).Unwrap())
+	assert.Equal(t, 1.5, roundTripBinary(t, Some(1.5)).Unwrap())
+	assert.Equal(t, float32(0.1), roundTripBinary(t, Some(float32(0.1))).Unwrap())
+	assert.Equal(t, 1+2i, roundTripBinary(t, Some(1+2i)).Unwrap())
+	assert.Equal(t, complex64(3-4i), roundTripBinary(t, Some(complex64(3-4i))).Unwrap())
+	assert.True(t, roundTripBinary(t, Some(true)).Unwrap())
+	assert.Equal(t, level(3), roundTripBinary(t, Some(level(3))).Unwrap())
+	assert.Equal(t, []int{1, 2}, roundTripBinary(t, Some([]int{1, 2})).Unwrap())
+	assert.True(t, roundTripBinary(t, Some([]int{})).IsSome())
+	assert.Empty(t, roundTripBinary(t, Some([]int{})).Unwrap())
+	assert.True(t, roundTripBinary(t, Some([]byte{})).IsSome())
+	assert.Empty(t, roundTripBinary(t, Some([]byte{})).Unwrap())
+	assert.Equal(t, testStructExported{A: 1, B: "b"}, roundTripBinary(t, Some(testStructExported{A: 1, B: "b"})).Unwrap())
+	assert.Equal(t, 7, *roundTripBinary(t, Some(ptr(7))).Unwrap())
+	assert.Equal(t, 5, roundTripBinary(t, Some(Some(5))).Unwrap().Unwrap())
+
+	now := time.Now()
+	assert.True(t, now.Equal(roundTripBinary(t, Some(now)).Unwrap()))
+}
+
+type testStructExported struct {
+	A int
+	B string
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestOption_MarshalBinary_None(t *testing.T) {
+	out := roundTripBinary(t, None[int](nil))
+	assert.True(t, out.IsNone())
+	assert.Nil(t, out.Error())
+
+	out = roundTripBinary(t, None[int](errors.New("not found")))
+	assert.True(t, out.IsNone())
+	require.Error(t, out.Error())
+	assert.Equal(t, "not found", out.Error().Error())
+}
+
+func TestOption_MarshalBinary_Compact(t *testing.T) {
+	data, err := Some(1).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{binaryVersion, binarySome, 2}, data)
+
+	data, err = None[int](nil).MarshalBinary()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{binaryVersion, 0}, data)
+
+	data, err = Some("hi").AppendBinary([]byte("prefix"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("prefix\x01\x01hi"), data)
+}
+
+func TestOption_UnmarshalBinary_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		into func([]byte) error
+	}{
+		{"empty", nil, new(Option[int]).UnmarshalBinary},
+		{"short", []byte{binaryVersion}, new(Option[int]).UnmarshalBinary},
+		{"version", []byte{99, binarySome, 2}, new(Option[int]).UnmarshalBinary},
+		{"flags", []byte{binaryVersion, 0xff}, new(Option[int]).UnmarshalBinary},
+		{"none payload", []byte{binaryVersion, 0, 1}, new(Option[int]).UnmarshalBinary},
+		{"missing value", []byte{binaryVersion, binarySome}, new(Option[int]).UnmarshalBinary},
+		{"truncated varint", []byte{binaryVersion, binarySome, 0x80}, new(Option[int]).UnmarshalBinary},
+		{"trailing bytes", []byte{binaryVersion, binarySome, 2, 2}, new(Option[int]).UnmarshalBinary},
+		{"overflow", []byte{binaryVersion, binarySome, 0x80, 0x04}, new(Option[int8]).UnmarshalBinary},
+		{"bad bool", []byte{binaryVersion, binarySome, 2}, new(Option[bool]).UnmarshalBinary},
+		{"bad float", []byte{binaryVersion, binarySome, 1, 2, 3}, new(Option[float64]).UnmarshalBinary},
+		{"bad gob", []byte{binaryVersion, binarySome, 1, 2, 3}, new(Option[testStructExported]).UnmarshalBinary},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.into(tt.data), ErrInvalidBinary)
+		})
+	}
+}
+
+func TestOption_UnmarshalBinary_ErrorKeepsValue(t *testing.T) {
+	o := Some(1)
+	assert.Error(t, o.UnmarshalBinary([]byte{binaryVersion, 0xff}))
+	assert.Equal(t, 1, o.Unwrap())
+}
+
+func TestOption_Gob(t *testing.T) {
+	in := binaryRecord{
+		Name:  "gopher",
+		Email: None[string](errors.New("not shared")),
+		Age:   Some(13),
+		Tags:  Some([]string{"go"}),
+		Seen:  Some(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(in))
+
+	var out binaryRecord
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&out))
+	assert.Equal(t, "gopher", out.Name)
+	assert.True(t, out.Email.IsNone())
+	assert.EqualError(t, out.Email.Error(), "not shared")
+	assert.Equal(t, 13, out.Age.Unwrap())
+	assert.Equal(t, []string{"go"}, out.Tags.Unwrap())
+	assert.True(t, in.Seen.Equal(out.Seen))
+}
+
+func FuzzOption_Binary_Int(f *testing.F) {
+	f.Add(true, int64(0), "")
+	f.Add(true, int64(math.MinInt64), "")
+	f.Add(false, int64(0), "not found")
+	f.Add(false, int64(0), "")
+
+	f.Fuzz(func(t *testing.T, some bool, n int64, msg string) {
+		in := None[int64](nil)
+		switch {
+		case some:
+			in = Some(n)
+		case msg != "":
+			in = None[int64](errors.New(msg))
+		}
+
+		out := roundTripBinary(t, in)
+		assert.Equal(t, in.IsSome(), out.IsSome())
+		if some {
+			assert.Equal(t, n, out.Unwrap())
+		} else if msg != "" {
+			assert.EqualError(t, out.Error(), msg)
+		} else {
+			assert.Nil(t, out.Error())
+		}
+	})
+}
+
+func FuzzOption_Binary_String(f *testing.F) {
+	f.Add("hello", 1.5, []byte("hello"))
+	f.Add("", math.Inf(-1), []byte{})
+	f.Add("\x00\xff", math.NaN(), []byte{0, 0xff})
+
+	f.Fuzz(func(t *testing.T, s string, x float64, b []byte) {
+		assert.Equal(t, s, roundTripBinary(t, Some(s)).Unwrap())
+
+		if b != nil {
+			out := roundTripBinary(t, Some(b))
+			assert.True(t, out.IsSome())
+			assert.Equal(t, len(b), len(out.Unwrap()))
+			assert.True(t, bytes.Equal(b, out.Unwrap()))
+		}
+
+		ints := make([]int, len(b))
+		for i, c := range b {
+			ints[i] = int(c)
+		}
+		assert.Equal(t, len(ints), len(roundTripBinary(t, Some(ints)).Unwrap()))
+
+		got := roundTripBinary(t, Some(x)).Unwrap()
+		assert.Equal(t, math.Float64bits(x), math.Float64bits(got))
+
+		rec := roundTripBinary(t, Some(testStructExported{A: len(s), B: s})).Unwrap()
+		assert.Equal(t, testStructExported{A: len(s), B: s}, rec)
+	})
+}
+
+func FuzzOption_UnmarshalBinary(f *testing.F) {
+	for _, o := range []Option[int]{Some(0), Some(-1 << 40), None[int](nil), None[int](errors.New("x"))} {
+		data, err := o.MarshalBinary()
+		require.NoError(f, err)
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var o Option[int]
+		if err := o.UnmarshalBinary(data); err != nil {
+			return
+		}
+		again, err := o.MarshalBinary()
+		require.NoError(t, err)
+
+		var back Option[int]
+		require.NoError(t, back.UnmarshalBinary(again))
+		assert.True(t, o.Equal(back))
+	})
+}
+
+func BenchmarkOption_MarshalBinary(b *testing.B) {
+	some := Some(42)
+	for i := 0; i < b.N; i++ {
+		_, _ = some.MarshalBinary()
+	}
+}
@@ -0,0 +1,84 @@
+package option
+
+import (
+  "cmp"
+  "reflect"
+)
+
+// Equal reports whether two options are equal. Two Some options are equal if
+// their values are equal; any two None options are equal regardless of the
+// errors they carry.
+//
+// Example:
+//
+//	option.Equal(option.Some(1), option.Some(1))                 // true
+//	option.Equal(option.None[int](errA), option.None[int](errB)) // true
+//	option.Equal(option.Some(1), option.None[int](nil))          // false
+func Equal[T comparable](a, b Option[T]) bool {
+  return EqualFunc(a, b, func(x, y T) bool { return x == y })
+}
+
+// EqualFunc is like Equal but uses eq to compare the contained values.
+// eq is only called when both options are Some.
+//
+// Example:
+//
+//	option.EqualFunc(option.Some("Go"), option.Some("GO"), strings.EqualFold) // true
+func EqualFunc[T, U any](a Option[T], b Option[U], eq func(T, U) bool) bool {
+  if !a.ok || !b.ok {
+    return a.ok == b.ok
+  }
+  return eq(a.some, b.some)
+}
+
+// Compare returns an integer comparing two options, ordering None before any
+// Some value. The result is 0 if a == b, -1 if a < b, and +1 if a > b.
+// All None options compare equal regardless of the errors they carry.
+//
+// Compare can be passed directly to slices.SortFunc:
+//
+//	slices.SortFunc(ages, option.Compare[int])
+func Compare[T cmp.Ordered](a, b Option[T]) int {
+  return compare(a, b, -1)
+}
+
+// CompareNoneLast is like Compare but orders None after any Some value.
+//
+//	slices.SortFunc(ages, option.CompareNoneLast[int])
+func CompareNoneLast[T cmp.Ordered](a, b Option[T]) int {
+  return compare(a, b, +1)
+}
+
+// compare orders a and b, placing None according to the sign of none.
+func compare[T cmp.Ordered](a, b Option[T], none int) int {
+  switch {
+  case !a.ok && !b.ok:
+    return 0
+  case !a.ok:
+    return none
+  case !b.ok:
+    return -none
+  }
+  return cmp.Compare(a.some, b.some)
+}
+
+// Equal reports whether o and other are equal. Any two None options are
+// equal regardless of the errors they carry. Two Some options are compared
+// with T's own Equal(T) bool method if it has one, and with reflect.DeepEqual
+// otherwise.
+//
+// The method makes Option work with tooling such as go-cmp, which would
+// otherwise refuse to compare the unexported fields.
+//
+// Example:
+//
+//	opt := option.Some(time.Now())
+//	opt.Equal(opt) // true
+func (o Option[T]) Equal(other Option[T]) bool {
+  return EqualFunc(o, other, func(x, y T) bool {
+    if e, ok := any(x).(interface{ Equal(T) bool }); ok {
+      return e.Equal(y)
+    }
+    return reflect.DeepEqual(x, y)
+  })
+}
@@ -0,0 +1,110 @@
+package option
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEqual(t *testing.T) {
+	assert.True(t, Equal(Some(1), Some(1)))
+	assert.False(t, Equal(Some(1), Some(2)))
+	assert.False(t, Equal(Some(1), None[int](nil)))
+	assert.False(t, Equal(None[int](nil), Some(1)))
+	assert.True(t, Equal(None[int](errors.New("a")), None[int](errors.New("b"))))
+}
+
+func TestEqualFunc(t *testing.T) {
+	assert.True(t, EqualFunc(Some("Go"), Some("GO"), strings.EqualFold))
+	assert.False(t, EqualFunc(Some("Go"), Some("Rust"), strings.EqualFold))
+	assert.True(t, EqualFunc(None[string](nil), None[string](nil), strings.EqualFold))
+
+	called := false
+	EqualFunc(Some(1), None[int](nil), func(int, int) bool {
+		called = true
+		return true
+	})
+	assert.False(t, called)
+}
+
+func TestCompare(t *testing.T) {
+	assert.Equal(t, 0, Compare(Some(1), Some(1)))
+	assert.Equal(t, -1, Compare(Some(1), Some(2)))
+	assert.Equal(t, 1, Compare(Some(2), Some(1)))
+	assert.Equal(t, -1, Compare(None[int](nil), Some(1)))
+	assert.Equal(t, 1, Compare(Some(1), None[int](nil)))
+	assert.Equal(t, 0, Compare(None[int](errors.New("a")), None[int](nil)))
+}
+
+func TestCompareNoneLast(t *testing.T) {
+	assert.Equal(t, -1, CompareNoneLast(Some(1), Some(2)))
+	assert.Equal(t, 1, CompareNoneLast(None[int](nil), Some(1)))
+	assert.Equal(t, -1, CompareNoneLast(Some(1), None[int](nil)))
+	assert.Equal(t, 0, CompareNoneLast(None[int](nil), None[int](nil)))
+}
+
+func TestCompare_SortFunc(t *testing.T) {
+	values := []Option[int]{Some(3), None[int](nil), Some(1), Some(2)}
+
+	first := slices.Clone(values)
+	slices.SortFunc(first, Compare[int])
+	assert.True(t, first[0].IsNone())
+	assert.Equal(t, []int{1, 2, 3}, []int{first[1].Unwrap(), first[2].Unwrap(), first[3].Unwrap()})
+
+	last := slices.Clone(values)
+	slices.SortFunc(last, CompareNoneLast[int])
+	assert.True(t, last[3].IsNone())
+	assert.Equal(t, []int{1, 2, 3}, []int{last[0].Unwrap(), last[1].Unwrap(), last[2].Unwrap()})
+}
+
+func TestCompare_Dedupe(t *testing.T) {
+	values := []Option[string]{Some("a"), None[string](nil), Some("a"), None[string](errors.New("x"))}
+	slices.SortFunc(values, Compare[string])
+	values = slices.CompactFunc(values, Equal[string])
+	assert.Len(t, values, 2)
+}
+
+func TestOption_Equal(t *testing.T) {
+	assert.True(t, Some([]int{1, 2}).Equal(Some([]int{1, 2})))
+	assert.False(t, Some([]int{1, 2}).Equal(Some([]int{2, 1})))
+	assert.True(t, None[[]int](errors.New("a")).Equal(None[[]int](nil)))
+	assert.False(t, Some(testStruct{1}).Equal(None[testStruct](nil)))
+}
+
+func TestOption_Equal_UsesEqualMethod(t *testing.T) {
+	now := time.Now()
+	utc := now.UTC()
+	assert.True(t, Some(now).Equal(Some(utc)))
+	assert.False(t, Some(now).Equal(Some(now.Add(time.Second))))
+}
+
+func BenchmarkEqual(b *testing.B) {
+	x, y := Some(42), Some(42)
+	for i := 0; i < b.N; i++ {
+		Equal(x, y)
+	}
+}
+
+func BenchmarkCompare(b *testing.B) {
+	x, y := Some(42), None[int](nil)
+	for i := 0; i < b.N; i++ {
+		Compare(x, y)
+	}
+}
+
+func ExampleCompareNoneLast() {
+	ages := []Option[int]{Some(30), None[int](nil), Some(25)}
+	slices.SortFunc(ages, CompareNoneLast[int])
+	for _, age := range ages {
+		fmt.Println(age.IsSome(), age.UnwrapOr(-1))
+	}
+	// Output:
+	// true 25
+	// true 30
+	// false -1
+}
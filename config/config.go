@@ -0,0 +1,370 @@
+// Package config loads layered configuration into structs of option.Option
+// fields and records where every effective setting came from.
+//
+// A configuration struct declares each setting as an Option, optionally
+// grouped into nested structs:
+//
+//	type Settings struct {
+//		Host  option.Option[string] `json:"host" usage:"listen host"`
+//		Port  option.Option[int]    `json:"port" env:"PORT"`
+//		Debug option.Option[bool]   `json:"debug"`
+//		DB    struct {
+//			DSN option.Option[string] `json:"dsn"`
+//		} `json:"db"`
+//	}
+//
+// Sources are applied in order with option.Merge semantics, so the last source
+// that sets a field wins:
+//
+//	fs := flag.NewFlagSet("app", flag.ExitOnError)
+//	flags := config.Flags[Settings](fs)
+//	printConfig := fs.Bool("print-config", false, "print the effective configuration")
+//	fs.Parse(os.Args[1:])
+//
+//	cfg, err := config.Load[Settings](
+//		config.Defaults(Settings{Port: option.Some(8080)}),
+//		config.File("app.json"),
+//		config.Env("APP_"),
+//		flags,
+//	)
+//	if *printConfig {
+//		cfg.WriteTable(os.Stdout)
+//	}
+//
+// Field names map to keys in each source as follows: JSON files use the json
+// tag or the field name, as encoding/json does; environment variables use the
+// prefix followed by the env tag or the upper snake case field name, joined
+// with underscores for nested structs (APP_DB_DSN); flags use the flag tag or
+// the kebab case field name, joined with dashes (-db-dsn).
+//
+// Environment variables and flags are decoded with the option.Option
+// UnmarshalText method. An empty value leaves the setting None, even for
+// string settings.
+package config
+
+import (
+  "encoding"
+  "encoding/json"
+  "flag"
+  "fmt"
+  "io"
+  "os"
+  "reflect"
+  "strings"
+  "sync"
+  "text/tabwriter"
+  "unicode"
+
+  "github.com/kalpio/option"
+)
+
+// Origin describes where a setting came from.
+type Origin struct {
+  // Source is the kind of source: "default", "file", "env" or "flag".
+  Source string
+
+  // Key identifies the setting within the source: the file name and JSON
+  // pointer, the environment variable or the flag name. It is empty for
+  // defaults.
+  Key string
+}
+
+// Config is the result of Load: the merged settings and their origins.
+type Config[S any] struct {
+  // Value holds the merged settings.
+  Value S
+
+  // Origins maps the dotted path of every setting that is Some, such as
+  // "DB.DSN", to the source that set it.
+  Origins map[string]Origin
+}
+
+// Origin returns the origin of the setting at the dotted field path, and
+// false if no source set it.
+func (c *Config[S]) Origin(path string) (Origin, bool) {
+  o, ok := c.Origins[path]
+  return o, ok
+}
+
+// WriteTable writes a table of all settings with their effective values and
+// origins to w, in field order. It is meant for a --print-config flag.
+//
+//	FIELD   VALUE      SOURCE   KEY
+//	Host    localhost  default
+//	Port    9090       env      APP_PORT
+//	Debug   <none>
+func (c *Config[S]) WriteTable(w io.Writer) error {
+  tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+  fmt.Fprintln(tw, "FIELD\tVALUE\tSOURCE\tKEY")
+
+  v := reflect.ValueOf(&c.Value).Elem()
+  for _, l := range leavesOf(v.Type()) {
+    value := "<none>"
+    if x, ok := v.FieldByIndex(l.index).Interface().(option.Optional).Interface(); ok {
+      value = fmt.Sprint(x)
+    }
+    o := c.Origins[l.path]
+    fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", l.path, value, o.Source, o.Key)
+  }
+  return tw.Flush()
+}
+
+// Source is one layer of configuration passed to Load.
+type Source struct {
+  kind string
+
+  // typ is the settings type a source is bound to, or nil.
+  typ reflect.Type
+
+  // load returns the layer for the settings type t, and the key of a
+  // setting within the source.
+  load func(t reflect.Type) (reflect.Value, func(leaf) string, error)
+}
+
+// Load builds the settings S from the sources, applied in order, and
+// records the origin of every setting.
+func Load[S any](sources ...Source) (*Config[S], error) {
+  t := reflect.TypeFor[S]()
+  if t.Kind() != reflect.Struct {
+    return nil, fmt.Errorf("config: settings type %s is not a struct", t)
+  }
+
+  cfg := &Config[S]{Origins: map[string]Origin{}}
+  layers := make([]S, 0, len(sources))
+  for _, src := range sources {
+    if src.typ != nil && src.typ != t {
+      return nil, fmt.Errorf("config: %s source is bound to %s, not %s", src.kind, src.typ, t)
+    }
+    layer, keyOf, err := src.load(t)
+    if err != nil {
+      return nil, err
+    }
+    for _, l := range leavesOf(t) {
+      if layer.FieldByIndex(l.index).Interface().(option.Optional).IsSome() {
+        cfg.Origins[l.path] = Origin{Source: src.kind, Key: keyOf(l)}
+      }
+    }
+    layers = append(layers, layer.Interface().(S))
+  }
+
+  cfg.Value = option.Merge(layers...)
+  return cfg, nil
+}
+
+// Defaults returns a source holding the default settings.
+func Defaults[S any](defaults S) Source {
+  return Source{
+    kind: "default",
+    typ:  reflect.TypeFor[S](),
+    load: func(t reflect.Type) (reflect.Value, func(leaf) string, error) {
+      return reflect.ValueOf(defaults), func(leaf) string { return "" }, nil
+    },
+  }
+}
+
+// TagDefaults returns a source holding the defaults declared in `default`
+// struct tags, as filled in by option.ApplyDefaults. Settings it provides have
+// the origin "default", like those of Defaults.
+//
+//	type Settings struct {
+//		Port option.Option[int] `json:"port" default:"8080"`
+//	}
+//
+//	cfg, err := config.Load[Settings](config.TagDefaults(), config.Env("APP_"))
+func TagDefaults() Source {
+  return Source{
+    kind: "default",
+    load: func(t reflect.Type) (reflect.Value, func(leaf) string, error) {
+      layer := reflect.New(t)
+      if err := option.ApplyDefaults(layer.Interface()); err != nil {
+        return reflect.Value{}, nil, fmt.Errorf("config: %w", err)
+      }
+      return layer.Elem(), func(leaf) string { return "" }, nil
+    },
+  }
+}
+
+// File returns a source that reads settings from the JSON file at path.
+// Load fails if the file cannot be read or decoded.
+func File(path string) Source {
+  return Source{
+    kind: "file",
+    load: func(t reflect.Type) (reflect.Value, func(leaf) string, error) {
+      data, err := os.ReadFile(path)
+      if err != nil {
+        return reflect.Value{}, nil, fmt.Errorf("config: %w", err)
+      }
+      layer := reflect.New(t)
+      if err := json.Unmarshal(data, layer.Interface()); err != nil {
+        return reflect.Value{}, nil, fmt.Errorf("config: %s: %w", path, err)
+      }
+      return layer.Elem(), func(l leaf) string { return path + "#" + l.pointer }, nil
+    },
+  }
+}
+
+// Env returns a source that reads settings from environment variables whose
+// names start with prefix.
+func Env(prefix string) Source {
+  return Source{
+    kind: "env",
+    load: func(t reflect.Type) (reflect.Value, func(leaf) string, error) {
+      layer := reflect.New(t).Elem()
+      for _, l := range leavesOf(t) {
+        raw, ok := os.LookupEnv(prefix + l.env)
+        if !ok || raw == "" {
+          continue
+        }
+        target := layer.FieldByIndex(l.index).Addr().Interface().(encoding.TextUnmarshaler)
+        if err := target.UnmarshalText([]byte(raw)); err != nil {
+          return reflect.Value{}, nil, fmt.Errorf("config: %s%s=%q: %w", prefix, l.env, raw, err)
+        }
+      }
+      return layer, func(l leaf) string { return prefix + l.env }, nil
+    },
+  }
+}
+
+// Flags defines a flag on fs for every setting of S and returns a source
+// holding the flags that were set. Call it before fs.Parse and pass the
+// source to Load after parsing. Flags that were not given on the command
+// line leave their settings None, so they do not override other sources.
+func Flags[S any](fs *flag.FlagSet) Source {
+  t := reflect.TypeFor[S]()
+  layer := reflect.New(t).Elem()
+  for _, l := range leavesOf(t) {
+    field := layer.FieldByIndex(l.index)
+    fs.Var(&flagValue{
+      target: field.Addr().Interface().(encoding.TextUnmarshaler),
+      isBool: field.Interface().(option.Optional).ElemType().Kind() == reflect.Bool,
+    }, l.flag, l.usage)
+  }
+
+  return Source{
+    kind: "flag",
+    typ:  t,
+    load: func(reflect.Type) (reflect.Value, func(leaf) string, error) {
+      return layer, func(l leaf) string { return "-" + l.flag }, nil
+    },
+  }
+}
+
+// flagValue is the flag.Value that decodes a flag into an option.
+type flagValue struct {
+  target encoding.TextUnmarshaler
+  isBool bool
+  text   string
+}
+
+func (f *flagValue) String() string {
+  return f.text
+}
+
+func (f *flagValue) Set(s string) error {
+  f.text = s
+  if s == "" {
+    return nil
+  }
+  return f.target.UnmarshalText([]byte(s))
+}
+
+func (f *flagValue) IsBoolFlag() bool {
+  return f.isBool
+}
+
+// leaf is an Option field of a settings struct, possibly nested.
+type leaf struct {
+  index   []int
+  path    string
+  pointer string
+  env     string
+  flag    string
+  usage   string
+}
+
+var leavesCache sync.Map
+
+// leavesOf returns the Option fields of the struct type t in field order.
+func leavesOf(t reflect.Type) []leaf {
+  if cached, ok := leavesCache.Load(t); ok {
+    return cached.([]leaf)
+  }
+
+  var leaves []leaf
+  var walk func(t reflect.Type, parent leaf)
+  walk = func(t reflect.Type, parent leaf) {
+    for i := 0; i < t.NumField(); i++ {
+      sf := t.Field(i)
+      if !sf.IsExported() {
+        continue
+      }
+      name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+      if name == "-" {
+        continue
+      }
+      if name == "" {
+        name = sf.Name
+      }
+      l := leaf{
+        index:   append(append([]int(nil), parent.index...), i),
+        path:    join(parent.path, sf.Name, "."),
+        pointer: parent.pointer + "/" + name,
+        env:     join(parent.env, tagOr(sf, "env", upperSnake(sf.Name)), "_"),
+        flag:    join(parent.flag, tagOr(sf, "flag", kebab(sf.Name)), "-"),
+        usage:   sf.Tag.Get("usage"),
+      }
+      switch {
+      case sf.Type.Implements(reflect.TypeFor[option.Optional]()):
+        leaves = append(leaves, l)
+      case sf.Type.Kind() == reflect.Struct:
+        walk(sf.Type, l)
+      }
+    }
+  }
+  walk(t, leaf{})
+
+  cached, _ := leavesCache.LoadOrStore(t, leaves)
+  return cached.([]leaf)
+}
+
+func tagOr(sf reflect.StructField, key, def string) string {
+  if tag := sf.Tag.Get(key); tag != "" {
+    return tag
+  }
+  return def
+}
+
+func join(parent, name, sep string) string {
+  if parent == "" {
+    return name
+  }
+  return parent + sep + name
+}
+
+// words splits a Go identifier into words: "HTTPPort" becomes
+// ["HTTP", "Port"] and "MaxConns" becomes ["Max", "Conns"].
+func words(s string) []string {
+  var out []string
+  runes := []rune(s)
+  start := 0
+  for i := 1; i < len(runes); i++ {
+    prev, cur := runes[i-1], runes[i]
+    next := rune(0)
+    if i+1 < len(runes) {
+      next = runes[i+1]
+    }
+    if unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && unicode.IsLower(next))) {
+      out = append(out, string(runes[start:i]))
+      start = i
+    }
+  }
+  return append(out, string(runes[start:]))
+}
+
+func upperSnake(s string) string {
+  return strings.ToUpper(strings.Join(words(s), "_"))
+}
+
+func kebab(s string) string {
+  return strings.ToLower(strings.Join(words(s), "-"))
+}
@@ -0,0 +1,202 @@
+package config
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dbSettings struct {
+	DSN      option.Option[string] `json:"dsn"`
+	MaxConns option.Option[int]    `json:"max_conns"`
+}
+
+type settings struct {
+	Host     option.Option[string]  `json:"host" usage:"listen host"`
+	Port     option.Option[int]     `json:"port" env:"LISTEN_PORT"`
+	Debug    option.Option[bool]    `json:"debug"`
+	Ratio    option.Option[float64] `json:"ratio" flag:"sample-rate"`
+	HTTPPath option.Option[string]  `json:"http_path"`
+	DB       dbSettings             `json:"db"`
+	Ignored  string
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "app.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad(t *testing.T) {
+	path := writeFile(t, `{"host":"file.example","port":8000,"db":{"dsn":"postgres://file"}}`)
+	t.Setenv("APP_LISTEN_PORT", "9000")
+	t.Setenv("APP_DB_MAX_CONNS", "20")
+	t.Setenv("APP_HTTP_PATH", "")
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags := Flags[settings](fs)
+	require.NoError(t, fs.Parse([]string{"-debug", "-sample-rate", "0.5"}))
+
+	cfg, err := Load[settings](
+		Defaults(settings{Host: option.Some("localhost"), Port: option.Some(8080), Debug: option.Some(false)}),
+		File(path),
+		Env("APP_"),
+		flags,
+	)
+	require.NoError(t, err)
+
+	s := cfg.Value
+	assert.Equal(t, "file.example", s.Host.Unwrap())
+	assert.Equal(t, 9000, s.Port.Unwrap())
+	assert.True(t, s.Debug.Unwrap())
+	assert.Equal(t, 0.5, s.Ratio.Unwrap())
+	assert.True(t, s.HTTPPath.IsNone())
+	assert.Equal(t, "postgres://file", s.DB.DSN.Unwrap())
+	assert.Equal(t, 20, s.DB.MaxConns.Unwrap())
+
+	assert.Equal(t, map[string]Origin{
+		"Host":        {Source: "file", Key: path + "#/host"},
+		"Port":        {Source: "env", Key: "APP_LISTEN_PORT"},
+		"Debug":       {Source: "flag", Key: "-debug"},
+		"Ratio":       {Source: "flag", Key: "-sample-rate"},
+		"DB.DSN":      {Source: "file", Key: path + "#/db/dsn"},
+		"DB.MaxConns": {Source: "env", Key: "APP_DB_MAX_CONNS"},
+	}, cfg.Origins)
+
+	_, ok := cfg.Origin("HTTPPath")
+	assert.False(t, ok)
+}
+
+func TestLoad_DefaultsOrigin(t *testing.T) {
+	cfg, err := Load[settings](Defaults(settings{Port: option.Some(8080)}))
+	require.NoError(t, err)
+
+	o, ok := cfg.Origin("Port")
+	require.True(t, ok)
+	assert.Equal(t, Origin{Source: "default"}, o)
+}
+
+func TestFlags_Unset(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags := Flags[settings](fs)
+	require.NoError(t, fs.Parse(nil))
+
+	cfg, err := Load[settings](Defaults(settings{Port: option.Some(8080)}), flags)
+	require.NoError(t, err)
+	assert.Equal(t, 8080, cfg.Value.Port.Unwrap())
+	assert.Equal(t, "default", cfg.Origins["Port"].Source)
+
+	for _, name := range []string{"host", "port", "debug", "sample-rate", "http-path", "db-dsn", "db-max-conns"} {
+		assert.NotNil(t, fs.Lookup(name), name)
+	}
+	assert.Equal(t, "listen host", fs.Lookup("host").Usage)
+}
+
+func TestTagDefaults(t *testing.T) {
+	type server struct {
+		Host    option.Option[string]        `default:"localhost"`
+		Port    option.Option[int]           `default:"8080"`
+		Timeout option.Option[time.Duration] `default:"30s"`
+	}
+	t.Setenv("APP_PORT", "9090")
+
+	cfg, err := Load[server](TagDefaults(), Env("APP_"))
+	require.NoError(t, err)
+	assert.Equal(t, "localhost", cfg.Value.Host.Unwrap())
+	assert.Equal(t, 9090, cfg.Value.Port.Unwrap())
+	assert.Equal(t, 30*time.Second, cfg.Value.Timeout.Unwrap())
+	assert.Equal(t, map[string]Origin{
+		"Host":    {Source: "default"},
+		"Port":    {Source: "env", Key: "APP_PORT"},
+		"Timeout": {Source: "default"},
+	}, cfg.Origins)
+
+	type invalid struct {
+		Port option.Option[int] `default:"http"`
+	}
+	_, err = Load[invalid](TagDefaults())
+	assert.ErrorIs(t, err, option.ErrInvalidDefault)
+}
+
+func TestLoad_Duration(t *testing.T) {
+	type timeouts struct {
+		Read  option.Option[time.Duration] `json:"read"`
+		Write option.Option[time.Duration] `json:"write"`
+	}
+	t.Setenv("APP_READ", "1.5s")
+
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	flags := Flags[timeouts](fs)
+	require.NoError(t, fs.Parse([]string{"-write", "2m"}))
+
+	cfg, err := Load[timeouts](Env("APP_"), flags)
+	require.NoError(t, err)
+	assert.Equal(t, 1500*time.Millisecond, cfg.Value.Read.Unwrap())
+	assert.Equal(t, 2*time.Minute, cfg.Value.Write.Unwrap())
+}
+
+func TestFlags_Invalid(t *testing.T) {
+	fs := flag.NewFlagSet("app", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	Flags[settings](fs)
+	assert.Error(t, fs.Parse([]string{"-port", "eighty"}))
+}
+
+func TestLoad_Errors(t *testing.T) {
+	_, err := Load[settings](File(filepath.Join(t.TempDir(), "missing.json")))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	_, err = Load[settings](File(writeFile(t, `{"port":"eighty"}`)))
+	assert.Error(t, err)
+
+	t.Setenv("BAD_LISTEN_PORT", "eighty")
+	_, err = Load[settings](Env("BAD_"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `BAD_LISTEN_PORT="eighty"`)
+
+	type other struct {
+		Port option.Option[int]
+	}
+	_, err = Load[other](Defaults(settings{}))
+	assert.Error(t, err)
+
+	_, err = Load[int]()
+	assert.Error(t, err)
+}
+
+func TestConfig_WriteTable(t *testing.T) {
+	t.Setenv("APP_LISTEN_PORT", "9090")
+	cfg, err := Load[settings](
+		Defaults(settings{Host: option.Some("localhost")}),
+		Env("APP_"),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, cfg.WriteTable(&buf))
+	assert.Equal(t, ""+
+		"FIELD        VALUE      SOURCE   KEY\n"+
+		"Host         localhost  default  \n"+
+		"Port         9090       env      APP_LISTEN_PORT\n"+
+		"Debug        <none>              \n"+
+		"Ratio        <none>              \n"+
+		"HTTPPath     <none>              \n"+
+		"DB.DSN       <none>              \n"+
+		"DB.MaxConns  <none>              \n", buf.String())
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, "HTTP_PORT", upperSnake("HTTPPort"))
+	assert.Equal(t, "MAX_CONNS", upperSnake("MaxConns"))
+	assert.Equal(t, "ID", upperSnake("ID"))
+	assert.Equal(t, "DB_HOST", upperSnake("DBHost"))
+	assert.Equal(t, "http-port", kebab("HTTPPort"))
+}
@@ -0,0 +1,303 @@
+package option
+
+import (
+  "bytes"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "reflect"
+  "slices"
+  "strings"
+  "sync"
+)
+
+var (
+  ErrInvalidJSON = errors.New("option: invalid JSON document")
+)
+
+// FieldError describes a problem with one member of a JSON document.
+type FieldError struct {
+  // Pointer is the RFC 6901 JSON pointer of the member, such as
+  // "/address/city". The empty pointer refers to the whole document.
+  Pointer string
+
+  // Err is ErrRequired for a missing required member, or the error that
+  // decoding the member produced.
+  Err error
+}
+
+func (e *FieldError) Error() string {
+  if e.Err == ErrRequired {
+    return e.Pointer + ": missing required value"
+  }
+  return e.Pointer + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+  return e.Err
+}
+
+// DecodeError is returned by DecodeJSON for a document that is well-formed
+// JSON but does not fit the target type. It lists every problem found, in
+// field order, and matches ErrInvalidJSON with errors.Is; it also matches
+// ErrRequired when a required member is missing.
+type DecodeError struct {
+  Fields []*FieldError
+}
+
+func (e *DecodeError) Error() string {
+  msgs := make([]string, len(e.Fields))
+  for i, f := range e.Fields {
+    msgs[i] = f.Error()
+  }
+  return ErrInvalidJSON.Error() + ": " + strings.Join(msgs, "; ")
+}
+
+func (e *DecodeError) Unwrap() []error {
+  errs := make([]error, len(e.Fields))
+  for i, f := range e.Fields {
+    errs[i] = f
+  }
+  return errs
+}
+
+func (e *DecodeError) Is(target error) bool {
+  return target == ErrInvalidJSON
+}
+
+// DecodeJSON decodes the JSON document data into a value of type S, which is
+// typically a struct of Options describing a request body, and checks that
+// every field tagged `option:"required"` is present.
+//
+// Unlike json.Unmarshal, DecodeJSON does not stop at the first problem. It
+// decodes every member it can and returns a *DecodeError listing each missing
+// required member and each malformed member by its JSON pointer. A required
+// Option field is missing when its member is absent or null; any other
+// required field is missing when its member is absent. Nested structs, Options
+// of structs and slices of structs are checked recursively. Members are
+// matched to fields as encoding/json does, and unknown members are ignored.
+//
+// Data that is not valid JSON is reported with an error wrapping ErrInvalidJSON
+// and the syntax error.
+//
+// Example:
+//
+//	type CreateUser struct {
+//		Name  option.Option[string] `json:"name" option:"required"`
+//		Email option.Option[string] `json:"email" option:"required"`
+//		Age   option.Option[int]    `json:"age"`
+//	}
+//
+//	req, err := option.DecodeJSON[CreateUser]([]byte(`{"name":"gopher","age":"ten"}`))
+//	// err: option: invalid JSON document: /email: missing required value; /age: json: cannot unmarshal ...
+func DecodeJSON[S any](data []byte) (S, error) {
+  var out S
+  if !json.Valid(data) {
+    var syntax any
+    err := json.Unmarshal(data, &syntax)
+    return out, fmt.Errorf("%w: %w", ErrInvalidJSON, err)
+  }
+
+  var d jsonDecoder
+  d.value(reflect.ValueOf(&out).Elem(), bytes.TrimSpace(data), "")
+  if len(d.errs) > 0 {
+    return out, &DecodeError{Fields: d.errs}
+  }
+  return out, nil
+}
+
+// decodeField is one field of a decodePlan.
+type decodeField struct {
+  name     string
+  index    []int
+  required bool
+  optional bool
+}
+
+// decodePlan lists the JSON members of a struct type in field order.
+type decodePlan struct {
+  fields []decodeField
+}
+
+var decodePlanCache sync.Map
+
+// decodePlanOf returns the cached decode plan for the struct type t.
+func decodePlanOf(t reflect.Type) *decodePlan {
+  if cached, ok := decodePlanCache.Load(t); ok {
+    return cached.(*decodePlan)
+  }
+
+  plan := &decodePlan{}
+  for name, index := range jsonFieldsOf(t) {
+    sf := t.FieldByIndex(index)
+    plan.fields = append(plan.fields, decodeField{
+      name:     name,
+      index:    index,
+      required: hasTagOption(sf.Tag.Get("option"), "required"),
+      optional: sf.Type.Implements(optionalType),
+    })
+  }
+  slices.SortFunc(plan.fields, func(a, b decodeField) int {
+    return slices.Compare(a.index, b.index)
+  })
+
+  cached, _ := decodePlanCache.LoadOrStore(t, plan)
+  return cached.(*decodePlan)
+}
+
+var (
+  jsonUnmarshalerType = reflect.TypeFor[json.Unmarshaler]()
+)
+
+// walkable reports whether values of type t are decoded member by member
+// rather than handed to encoding/json as a whole.
+func walkable(t reflect.Type) bool {
+  switch t.Kind() {
+  case reflect.Struct:
+    p := reflect.PointerTo(t)
+    return !p.Implements(jsonUnmarshalerType) && !p.Implements(textUnmarshalerType)
+  case reflect.Slice, reflect.Array, reflect.Pointer:
+    return walkable(t.Elem())
+  }
+  return false
+}
+
+// jsonDecoder collects the problems found while decoding a document.
+type jsonDecoder struct {
+  errs []*FieldError
+}
+
+func (d *jsonDecoder) fail(pointer string, err error) {
+  d.errs = append(d.errs, &FieldError{Pointer: pointer, Err: err})
+}
+
+// value decodes raw into the settable value v and reports whether it did so
+// without problems.
+func (d *jsonDecoder) value(v reflect.Value, raw json.RawMessage, pointer string) bool {
+  n := len(d.errs)
+  null := string(raw) == "null"
+
+  if s, ok := v.Addr().Interface().(OptionalSetter); ok && !null && walkable(s.ElemType()) {
+    elem := reflect.New(s.ElemType()).Elem()
+    if d.value(elem, raw, pointer) {
+      s.SetSome(elem.Interface())
+    }
+    return len(d.errs) == n
+  }
+
+  switch {
+  case null && v.Kind() == reflect.Struct && walkable(v.Type()):
+    // A null struct leaves its fields unset, so its required fields are
+    // still reported as missing.
+    d.object(v, nil, pointer)
+  case !walkable(v.Type()) || null:
+    if err := json.Unmarshal(raw, v.Addr().Interface()); err != nil {
+      d.fail(pointer, err)
+    }
+  case v.Kind() == reflect.Pointer:
+    if v.IsNil() {
+      v.Set(reflect.New(v.Type().Elem()))
+    }
+    d.value(v.Elem(), raw, pointer)
+  case v.Kind() == reflect.Struct:
+    d.object(v, raw, pointer)
+  default:
+    d.array(v, raw, pointer)
+  }
+  return len(d.errs) == n
+}
+
+// object decodes the JSON object raw into the struct v. A nil raw stands for
+// an absent member and only reports missing required fields.
+func (d *jsonDecoder) object(v reflect.Value, raw json.RawMessage, pointer string) {
+  var members map[string]json.RawMessage
+  if raw != nil {
+    if raw[0] != '{' {
+      d.fail(pointer, &json.UnmarshalTypeError{Value: jsonKind(raw), Type: v.Type()})
+      return
+    }
+    if err := json.Unmarshal(raw, &members); err != nil {
+      d.fail(pointer, err)
+      return
+    }
+  }
+
+  for _, f := range decodePlanOf(v.Type()).fields {
+    field := v.FieldByIndex(f.index)
+    fieldPointer := pointer + "/" + escapePointer(f.name)
+    member, found := lookupMember(members, f.name)
+    switch {
+    case found:
+      if !d.value(field, member, fieldPointer) {
+        continue
+      }
+    case field.Kind() == reflect.Struct && walkable(field.Type()):
+      d.object(field, nil, fieldPointer)
+    }
+
+    missing := !found || (f.optional && field.Interface().(Optional).IsNone())
+    if f.required && missing {
+      d.fail(fieldPointer, ErrRequired)
+    }
+  }
+}
+
+// array decodes the JSON array raw into the slice or array v.
+func (d *jsonDecoder) array(v reflect.Value, raw json.RawMessage, pointer string) {
+  if raw[0] != '[' {
+    d.fail(pointer, &json.UnmarshalTypeError{Value: jsonKind(raw), Type: v.Type()})
+    return
+  }
+  var elems []json.RawMessage
+  if err := json.Unmarshal(raw, &elems); err != nil {
+    d.fail(pointer, err)
+    return
+  }
+
+  if v.Kind() == reflect.Slice {
+    v.Set(reflect.MakeSlice(v.Type(), len(elems), len(elems)))
+  } else {
+    v.SetZero()
+  }
+  for i, elem := range elems {
+    if i >= v.Len() {
+      break
+    }
+    d.value(v.Index(i), elem, fmt.Sprintf("%s/%d", pointer, i))
+  }
+}
+
+// lookupMember finds the member for a field name, preferring an exact match
+// and falling back to a case-insensitive one like encoding/json.
+func lookupMember(members map[string]json.RawMessage, name string) (json.RawMessage, bool) {
+  if raw, ok := members[name]; ok {
+    return raw, true
+  }
+  for n, raw := range members {
+    if strings.EqualFold(n, name) {
+      return raw, true
+    }
+  }
+  return nil, false
+}
+
+// jsonKind names the kind of the JSON value raw as encoding/json does in
+// its type errors.
+func jsonKind(raw json.RawMessage) string {
+  switch raw[0] {
+  case '{':
+    return "object"
+  case '[':
+    return "array"
+  case '"':
+    return "string"
+  case 't', 'f':
+    return "bool"
+  }
+  return "number"
+}
+
+// escapePointer escapes a member name for use as a JSON pointer token.
+func escapePointer(name string) string {
+  return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
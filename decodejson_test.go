@@ -0,0 +1,123 @@
+package option
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type decodeAddress struct {
+	Street Option[string] `json:"street" option:"required"`
+	City   Option[string] `json:"city" option:"required"`
+}
+
+type decodeItem struct {
+	SKU      string      `json:"sku" option:"required"`
+	Quantity Option[int] `json:"qty"`
+}
+
+type decodeOrder struct {
+	Customer Option[string]        `json:"customer" option:"required"`
+	Note     Option[string]        `json:"note"`
+	Due      Option[time.Time]     `json:"due"`
+	Address  decodeAddress         `json:"address"`
+	Billing  Option[decodeAddress] `json:"billing"`
+	Items    []decodeItem          `json:"items" option:"required"`
+	Meta     map[string]any        `json:"meta"`
+	Weird    Option[string]        `json:"a/b~c"`
+	Internal string                `json:"-"`
+}
+
+func TestDecodeJSON(t *testing.T) {
+	order, err := DecodeJSON[decodeOrder]([]byte(`{
+		"customer": "gopher",
+		"due": "2024-06-01T10:00:00Z",
+		"address": {"street": "Main St", "city": "Lodz"},
+		"items": [{"sku": "A1", "qty": 2}, {"sku": "B2"}],
+		"meta": {"source": "web"},
+		"unknown": true
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, "gopher", order.Customer.Unwrap())
+	assert.True(t, order.Note.IsNone())
+	assert.Equal(t, time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), order.Due.Unwrap())
+	assert.Equal(t, "Lodz", order.Address.City.Unwrap())
+	assert.True(t, order.Billing.IsNone())
+	assert.Equal(t, []decodeItem{{SKU: "A1", Quantity: Some(2)}, {SKU: "B2"}}, order.Items)
+	assert.Equal(t, map[string]any{"source": "web"}, order.Meta)
+}
+
+func TestDecodeJSON_Errors(t *testing.T) {
+	_, err := DecodeJSON[decodeOrder]([]byte(`{
+		"customer": null,
+		"due": "tomorrow",
+		"address": {"street": 5},
+		"billing": {"city": "Lodz"},
+		"items": [{"qty": 1}, {"sku": "B2", "qty": "two"}],
+		"a/b~c": 1
+	}`))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalidJSON)
+	assert.ErrorIs(t, err, ErrRequired)
+
+	var decodeErr *DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	var pointers []string
+	for _, f := range decodeErr.Fields {
+		pointers = append(pointers, f.Pointer)
+	}
+	assert.Equal(t, []string{
+		"/customer",
+		"/due",
+		"/address/street",
+		"/address/city",
+		"/billing/street",
+		"/items/0/sku",
+		"/items/1/qty",
+		"/a~1b~0c",
+	}, pointers)
+	assert.Equal(t, ErrRequired, decodeErr.Fields[0].Err)
+	assert.Equal(t, "/customer: missing required value", decodeErr.Fields[0].Error())
+
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, decodeErr.Fields[6], &typeErr)
+}
+
+func TestDecodeJSON_MissingNested(t *testing.T) {
+	_, err := DecodeJSON[decodeOrder]([]byte(`{"customer":"gopher","items":[]}`))
+	assert.EqualError(t, err, "option: invalid JSON document: /address/street: missing required value; /address/city: missing required value")
+}
+
+func TestDecodeJSON_Null(t *testing.T) {
+	_, err := DecodeJSON[decodeOrder]([]byte("null"))
+	var decodeErr *DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	var pointers []string
+	for _, f := range decodeErr.Fields {
+		pointers = append(pointers, f.Pointer)
+	}
+	assert.Equal(t, []string{"/customer", "/address/street", "/address/city", "/items"}, pointers)
+
+	_, err = DecodeJSON[decodeOrder]([]byte(`{"customer":"gopher","items":[],"address":null}`))
+	assert.EqualError(t, err, "option: invalid JSON document: /address/street: missing required value; /address/city: missing required value")
+}
+
+func TestDecodeJSON_Malformed(t *testing.T) {
+	_, err := DecodeJSON[decodeOrder]([]byte(`{"customer":`))
+	assert.ErrorIs(t, err, ErrInvalidJSON)
+	var syntaxErr *json.SyntaxError
+	assert.ErrorAs(t, err, &syntaxErr)
+
+	_, err = DecodeJSON[decodeOrder]([]byte(`[1, 2]`))
+	var decodeErr *DecodeError
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, "", decodeErr.Fields[0].Pointer)
+
+	_, err = DecodeJSON[decodeOrder]([]byte(`{"customer":"x","address":{"street":"a","city":"b"},"items":{}}`))
+	require.ErrorAs(t, err, &decodeErr)
+	assert.Equal(t, "/items", decodeErr.Fields[0].Pointer)
+}
@@ -0,0 +1,187 @@
+package option
+
+import (
+  "errors"
+  "fmt"
+  "reflect"
+  "sync"
+)
+
+var (
+  ErrInvalidDefault = errors.New("option: invalid default")
+)
+
+// ApplyDefaults fills every None Option field of the struct that ptr points
+// to with the value of its `default:"..."` tag, converted with the same rules
+// as Parse. Fields that are already Some are left alone, and nested structs,
+// pointers to structs and Some options of structs are filled recursively.
+//
+// A default tag that cannot be parsed, or that is placed on a field that is
+// not an Option, is reported with an error wrapping ErrInvalidDefault, even if
+// the field is Some. The per-type plan is computed once and cached.
+//
+// Example:
+//
+//	type ListRequest struct {
+//		Limit  option.Option[int]           `json:"limit" default:"50"`
+//		Order  option.Option[string]        `json:"order" default:"asc"`
+//		Within option.Option[time.Duration] `json:"within" default:"24h"`
+//	}
+//
+//	req := ListRequest{Limit: option.Some(10)}
+//	err := option.ApplyDefaults(&req)
+//	// req.Limit is Some(10), req.Order is Some("asc"), req.Within is Some(24h)
+func ApplyDefaults(ptr any) error {
+  _, err := ApplyDefaultsReport(ptr)
+  return err
+}
+
+// ApplyDefaultsReport is like ApplyDefaults but also returns the dotted paths
+// of the fields it filled, such as "DB.Port", in field order.
+//
+// Example:
+//
+//	filled, err := option.ApplyDefaultsReport(&cfg)
+//	for _, path := range filled {
+//		log.Printf("%s: using default", path)
+//	}
+func ApplyDefaultsReport(ptr any) ([]string, error) {
+  v := reflect.ValueOf(ptr)
+  if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+    return nil, fmt.Errorf("option: ApplyDefaults needs a non-nil pointer to a struct, got %T", ptr)
+  }
+
+  var filled []string
+  if err := applyDefaults(v.Elem(), "", &filled); err != nil {
+    return nil, err
+  }
+  return filled, nil
+}
+
+// defaultField is one field of a defaultPlan.
+type defaultField struct {
+  name   string
+  index  int
+  text   string
+  tagged bool
+  nested bool
+}
+
+// defaultPlan lists the fields of a struct type that ApplyDefaults visits.
+type defaultPlan struct {
+  fields []defaultField
+  err    error
+}
+
+var defaultPlanCache sync.Map
+
+// defaultPlanOf returns the cached default plan for the struct type t.
+func defaultPlanOf(t reflect.Type) *defaultPlan {
+  if cached, ok := defaultPlanCache.Load(t); ok {
+    return cached.(*defaultPlan)
+  }
+
+  plan := &defaultPlan{}
+  for i := 0; i < t.NumField(); i++ {
+    sf := t.Field(i)
+    if !sf.IsExported() {
+      continue
+    }
+    f := defaultField{name: sf.Name, index: i}
+    f.text, f.tagged = sf.Tag.Lookup("default")
+    if f.tagged {
+      if !sf.Type.Implements(optionalType) {
+        plan.err = fmt.Errorf("%w: %s.%s is not an Option", ErrInvalidDefault, t, sf.Name)
+        break
+      }
+      elem := reflect.New(reflect.Zero(sf.Type).Interface().(Optional).ElemType()).Elem()
+      if err := unmarshalText([]byte(f.text), elem); err != nil {
+        plan.err = fmt.Errorf("%w: %s.%s: %q: %w", ErrInvalidDefault, t, sf.Name, f.text, err)
+        break
+      }
+    }
+    f.nested = structType(sf.Type) != nil
+    if f.tagged || f.nested {
+      plan.fields = append(plan.fields, f)
+    }
+  }
+
+  cached, _ := defaultPlanCache.LoadOrStore(t, plan)
+  return cached.(*defaultPlan)
+}
+
+// structType returns the struct type held by fields of type t, looking
+// through options and pointers, or nil if there is none.
+func structType(t reflect.Type) reflect.Type {
+  if t.Implements(optionalType) {
+    t = reflect.Zero(t).Interface().(Optional).ElemType()
+  }
+  if t.Kind() == reflect.Pointer {
+    t = t.Elem()
+  }
+  if t.Kind() != reflect.Struct || t.Implements(optionalType) {
+    return nil
+  }
+  return t
+}
+
+// applyDefaults fills the fields of the struct v, appending the paths of the
+// filled fields to filled.
+func applyDefaults(v reflect.Value, prefix string, filled *[]string) error {
+  plan := defaultPlanOf(v.Type())
+  if plan.err != nil {
+    return plan.err
+  }
+
+  for _, f := range plan.fields {
+    path := prefix + f.name
+    field := v.Field(f.index)
+
+    s, isOption := field.Addr().Interface().(OptionalSetter)
+    if f.tagged && s.IsNone() {
+      elem := reflect.New(s.ElemType()).Elem()
+      if err := unmarshalText([]byte(f.text), elem); err != nil {
+        return err
+      }
+      if err := s.SetSome(elem.Interface()); err != nil {
+        return err
+      }
+      *filled = append(*filled, path)
+    }
+    if !f.nested {
+      continue
+    }
+
+    switch {
+    case isOption:
+      value, ok := s.Interface()
+      if !ok {
+        continue
+      }
+      elem := reflect.New(s.ElemType()).Elem()
+      elem.Set(reflect.ValueOf(value))
+      if err := applyDefaultsTo(elem, path, filled); err != nil {
+        return err
+      }
+      if err := s.SetSome(elem.Interface()); err != nil {
+        return err
+      }
+    default:
+      if err := applyDefaultsTo(field, path, filled); err != nil {
+        return err
+      }
+    }
+  }
+  return nil
+}
+
+// applyDefaultsTo fills the struct v or the struct v points to, if any.
+func applyDefaultsTo(v reflect.Value, path string, filled *[]string) error {
+  if v.Kind() == reflect.Pointer {
+    if v.IsNil() {
+      return nil
+    }
+    v = v.Elem()
+  }
+  return applyDefaults(v, path+".", filled)
+}
@@ -0,0 +1,93 @@
+package option
+
+import (
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type defaultsDB struct {
+	Host    Option[string] `default:"localhost"`
+	Port    Option[int]    `default:"5432"`
+	Replica *defaultsDB
+}
+
+type defaultsConfig struct {
+	Name    Option[string]        `default:""`
+	Timeout Option[time.Duration] `default:"1.5s"`
+	Bind    Option[netip.Addr]    `default:"127.0.0.1"`
+	Ratio   Option[float64]       `default:"0.25"`
+	Debug   Option[bool]
+	DB      defaultsDB
+	Cache   Option[defaultsDB]
+	Plain   string
+}
+
+func TestApplyDefaults(t *testing.T) {
+	cfg := defaultsConfig{
+		Ratio: Some(0.5),
+		DB:    defaultsDB{Port: Some(6543), Replica: &defaultsDB{}},
+		Cache: Some(defaultsDB{Host: Some("cache")}),
+	}
+
+	filled, err := ApplyDefaultsReport(&cfg)
+	require.NoError(t, err)
+
+	assert.Equal(t, Some(""), cfg.Name)
+	assert.Equal(t, Some(1500*time.Millisecond), cfg.Timeout)
+	assert.Equal(t, Some(netip.MustParseAddr("127.0.0.1")), cfg.Bind)
+	assert.Equal(t, Some(0.5), cfg.Ratio)
+	assert.True(t, cfg.Debug.IsNone())
+	assert.Equal(t, "localhost", cfg.DB.Host.Unwrap())
+	assert.Equal(t, 6543, cfg.DB.Port.Unwrap())
+	assert.Equal(t, defaultsDB{Host: Some("localhost"), Port: Some(5432)}, *cfg.DB.Replica)
+	assert.Equal(t, defaultsDB{Host: Some("cache"), Port: Some(5432)}, cfg.Cache.Unwrap())
+
+	assert.Equal(t, []string{
+		"Name",
+		"Timeout",
+		"Bind",
+		"DB.Host",
+		"DB.Replica.Host",
+		"DB.Replica.Port",
+		"Cache.Port",
+	}, filled)
+}
+
+func TestApplyDefaults_Idempotent(t *testing.T) {
+	var cfg defaultsDB
+	require.NoError(t, ApplyDefaults(&cfg))
+	filled, err := ApplyDefaultsReport(&cfg)
+	require.NoError(t, err)
+	assert.Empty(t, filled)
+	assert.Equal(t, defaultsDB{Host: Some("localhost"), Port: Some(5432)}, cfg)
+}
+
+func TestApplyDefaults_Errors(t *testing.T) {
+	var bad struct {
+		Port Option[int] `default:"eighty"`
+	}
+	bad.Port = Some(80)
+	err := ApplyDefaults(&bad)
+	assert.ErrorIs(t, err, ErrInvalidDefault)
+	assert.Contains(t, err.Error(), `Port: "eighty"`)
+
+	var plain struct {
+		Port int `default:"80"`
+	}
+	assert.ErrorIs(t, ApplyDefaults(&plain), ErrInvalidDefault)
+
+	var nested struct {
+		Inner struct {
+			Port Option[uint8] `default:"300"`
+		}
+	}
+	assert.ErrorIs(t, ApplyDefaults(&nested), ErrInvalidDefault)
+
+	assert.Error(t, ApplyDefaults(defaultsDB{}))
+	assert.Error(t, ApplyDefaults((*defaultsDB)(nil)))
+	assert.Error(t, ApplyDefaults(new(int)))
+}
@@ -0,0 +1,84 @@
+package option
+
+import (
+  "encoding"
+  "errors"
+  "fmt"
+  "os"
+  "reflect"
+  "strings"
+)
+
+var (
+  ErrEnvUnset = errors.New("option: environment variable not set")
+)
+
+// EnvError records an environment variable whose value could not be parsed.
+type EnvError struct {
+  Name  string // name of the variable
+  Value string // raw value of the variable
+  Err   error  // underlying parse error
+}
+
+func (e *EnvError) Error() string {
+  return fmt.Sprintf("option: env %s=%q: %v", e.Name, e.Value, e.Err)
+}
+
+func (e *EnvError) Unwrap() error {
+  return e.Err
+}
+
+// Env looks up the environment variable name and parses its value as a T.
+// It returns None with ErrEnvUnset if the variable is not set, and None with
+// an *EnvError naming the variable and its raw value if the value cannot be
+// parsed, so missing and malformed settings can be told apart. A variable that
+// is set to the empty string is not unset: it parses to Some("") for strings
+// and to an empty slice for slices.
+//
+// Values are parsed with the same rules as Parse. Slices of any supported type
+// are read as comma-separated lists with surrounding spaces trimmed.
+//
+// Example:
+//
+//	port := option.Env[int]("PORT").UnwrapOr(8080)
+//	timeout := option.Env[time.Duration]("TIMEOUT") // from "1.5s"
+//	peers := option.Env[[]netip.Addr]("PEERS")      // from "10.0.0.1, 10.0.0.2"
+//
+//	if errors.Is(timeout.Error(), option.ErrEnvUnset) {
+//		// not configured
+//	}
+func Env[T any](name string) Option[T] {
+  raw, ok := os.LookupEnv(name)
+  if !ok {
+    return None[T](ErrEnvUnset)
+  }
+  var value T
+  if err := unmarshalEnv(raw, reflect.ValueOf(&value).Elem()); err != nil {
+    return None[T](&EnvError{Name: name, Value: raw, Err: err})
+  }
+  return Some(value)
+}
+
+var (
+  textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// unmarshalEnv decodes the value of an environment variable into the settable
+// value v. It extends unmarshalText with comma-separated slices.
+func unmarshalEnv(s string, v reflect.Value) error {
+  if v.Kind() == reflect.Slice && !reflect.PointerTo(v.Type()).Implements(textUnmarshalerType) {
+    slice := reflect.MakeSlice(v.Type(), 0, 0)
+    if s != "" {
+      parts := strings.Split(s, ",")
+      slice = reflect.MakeSlice(v.Type(), len(parts), len(parts))
+      for i, part := range parts {
+        if err := unmarshalEnv(strings.TrimSpace(part), slice.Index(i)); err != nil {
+          return err
+        }
+      }
+    }
+    v.Set(slice)
+    return nil
+  }
+  return unmarshalText([]byte(s), v)
+}
@@ -0,0 +1,61 @@
+package option
+
+import (
+	"net/netip"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnv(t *testing.T) {
+	t.Setenv("OPTION_TEST_STRING", "gopher")
+	t.Setenv("OPTION_TEST_EMPTY", "")
+	t.Setenv("OPTION_TEST_INT", "8080")
+	t.Setenv("OPTION_TEST_FLOAT", "0.25")
+	t.Setenv("OPTION_TEST_BOOL", "true")
+	t.Setenv("OPTION_TEST_DURATION", "1m30s")
+	t.Setenv("OPTION_TEST_ADDR", "10.0.0.1")
+	t.Setenv("OPTION_TEST_INTS", "1, 2,3")
+	t.Setenv("OPTION_TEST_ADDRS", "10.0.0.1, ::1")
+
+	assert.Equal(t, Some("gopher"), Env[string]("OPTION_TEST_STRING"))
+	assert.Equal(t, Some(""), Env[string]("OPTION_TEST_EMPTY"))
+	assert.Equal(t, Some(8080), Env[int]("OPTION_TEST_INT"))
+	assert.Equal(t, Some(0.25), Env[float64]("OPTION_TEST_FLOAT"))
+	assert.Equal(t, Some(true), Env[bool]("OPTION_TEST_BOOL"))
+	assert.Equal(t, Some(90*time.Second), Env[time.Duration]("OPTION_TEST_DURATION"))
+	assert.Equal(t, Some(netip.MustParseAddr("10.0.0.1")), Env[netip.Addr]("OPTION_TEST_ADDR"))
+	assert.Equal(t, Some([]int{1, 2, 3}), Env[[]int]("OPTION_TEST_INTS"))
+	assert.Equal(t, Some([]netip.Addr{netip.MustParseAddr("10.0.0.1"), netip.MustParseAddr("::1")}), Env[[]netip.Addr]("OPTION_TEST_ADDRS"))
+	assert.Equal(t, Some([]string{}), Env[[]string]("OPTION_TEST_EMPTY"))
+}
+
+func TestEnv_Unset(t *testing.T) {
+	o := Env[int]("OPTION_TEST_UNSET")
+	assert.True(t, o.IsNone())
+	assert.ErrorIs(t, o.Error(), ErrEnvUnset)
+	assert.Equal(t, 3000, o.UnwrapOr(3000))
+}
+
+func TestEnv_Malformed(t *testing.T) {
+	t.Setenv("OPTION_TEST_PORT", "eighty")
+	t.Setenv("OPTION_TEST_TIMEOUT", "soon")
+	t.Setenv("OPTION_TEST_PORTS", "80,x")
+
+	o := Env[int]("OPTION_TEST_PORT")
+	require.True(t, o.IsNone())
+	assert.NotErrorIs(t, o.Error(), ErrEnvUnset)
+	assert.ErrorIs(t, o.Error(), strconv.ErrSyntax)
+	var envErr *EnvError
+	require.ErrorAs(t, o.Error(), &envErr)
+	assert.Equal(t, "OPTION_TEST_PORT", envErr.Name)
+	assert.Equal(t, "eighty", envErr.Value)
+	assert.Contains(t, o.Error().Error(), `OPTION_TEST_PORT="eighty"`)
+
+	assert.ErrorAs(t, Env[time.Duration]("OPTION_TEST_TIMEOUT").Error(), &envErr)
+	assert.ErrorAs(t, Env[[]int]("OPTION_TEST_PORTS").Error(), &envErr)
+	assert.Equal(t, "80,x", envErr.Value)
+}
@@ -0,0 +1,93 @@
+package option
+
+import (
+  "encoding/json"
+  "errors"
+  "fmt"
+)
+
+var (
+  ErrInvalidEnvelope = errors.New("option: invalid JSON envelope")
+)
+
+// Envelope wraps an Option to select the JSON envelope encoding, which keeps
+// the error of a None option. Some options are encoded as {"some": value} and
+// None options as {"none": {"error": "message"}}, or {"none": {}} when they
+// carry no error.
+//
+// Decoding rebuilds a None option whose error has the encoded message. Errors
+// registered with RegisterError are restored as the registered value, so
+// errors.Is keeps working for sentinel errors such as ErrNilValue.
+//
+// Example:
+//
+//	type Reply struct {
+//		User option.Envelope[User] `json:"user"`
+//	}
+//
+//	reply := Reply{User: option.Envelope[User]{option.None[User](ErrUserNotFound)}}
+//	out, _ := json.Marshal(reply)
+//	// {"user":{"none":{"error":"user not found"}}}
+type Envelope[T any] struct {
+  Option[T]
+}
+
+// envelopeJSON is the wire representation of an Envelope.
+type envelopeJSON struct {
+  Some json.RawMessage `json:"some,omitempty"`
+  None *noneJSON       `json:"none,omitempty"`
+}
+
+type noneJSON struct {
+  Error *string `json:"error,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler using the envelope encoding.
+func (e Envelope[T]) MarshalJSON() ([]byte, error) {
+  if e.ok {
+    some, err := json.Marshal(e.some)
+    if err != nil {
+      return nil, err
+    }
+    return json.Marshal(envelopeJSON{Some: some})
+  }
+
+  none := &noneJSON{}
+  if e.err != nil {
+    msg := e.err.Error()
+    none.Error = &msg
+  }
+  return json.Marshal(envelopeJSON{None: none})
+}
+
+// UnmarshalJSON implements json.Unmarshaler using the envelope encoding. The
+// object must hold exactly one of the "some" and "none" keys; JSON null
+// decodes to None with a nil error. On error the envelope is left unchanged.
+func (e *Envelope[T]) UnmarshalJSON(data []byte) error {
+  if string(data) == "null" {
+    e.Option = None[T](nil)
+    return nil
+  }
+
+  var env envelopeJSON
+  if err := json.Unmarshal(data, &env); err != nil {
+    return err
+  }
+  switch {
+  case env.Some != nil && env.None == nil:
+    var value T
+    if err := json.Unmarshal(env.Some, &value); err != nil {
+      return err
+    }
+    e.Option = Some(value)
+  case env.None != nil && env.Some == nil:
+    var err error
+    if env.None.Error != nil {
+      err = decodeError(*env.None.Error)
+    }
+    e.Option = None[T](err)
+  default:
+    return fmt.Errorf(`%w: want exactly one of "some" and "none"`, ErrInvalidEnvelope)
+  }
+  return nil
+}
@@ -0,0 +1,98 @@
+package option
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errEnvelopeTest = errors.New("envelope test: user not found")
+
+func init() {
+	RegisterError(errEnvelopeTest)
+}
+
+type envelopeReply struct {
+	User  Envelope[string] `json:"user"`
+	Count Envelope[int]    `json:"count,omitzero"`
+}
+
+func TestEnvelope_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		value Envelope[int]
+		want  string
+	}{
+		{"some", Envelope[int]{Some(42)}, `{"some":42}`},
+		{"none with error", Envelope[int]{None[int](errors.New("boom"))}, `{"none":{"error":"boom"}}`},
+		{"none without error", Envelope[int]{None[int](nil)}, `{"none":{}}`},
+		{"zero", Envelope[int]{}, `{"none":{}}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json.Marshal(tt.value)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(out))
+		})
+	}
+}
+
+func TestEnvelope_UnmarshalJSON(t *testing.T) {
+	var e Envelope[int]
+	require.NoError(t, json.Unmarshal([]byte(`{"some":42}`), &e))
+	assert.Equal(t, 42, e.Unwrap())
+
+	require.NoError(t, json.Unmarshal([]byte(`{"none":{"error":"boom"}}`), &e))
+	assert.True(t, e.IsNone())
+	assert.EqualError(t, e.Error(), "boom")
+
+	require.NoError(t, json.Unmarshal([]byte(`{"none":{}}`), &e))
+	assert.True(t, e.IsNone())
+	assert.Nil(t, e.Error())
+
+	require.NoError(t, json.Unmarshal([]byte(`null`), &e))
+	assert.True(t, e.IsNone())
+}
+
+func TestEnvelope_UnmarshalJSON_Invalid(t *testing.T) {
+	for _, doc := range []string{`{}`, `{"some":1,"none":{}}`, `{"none":null}`} {
+		e := Envelope[int]{Some(1)}
+		assert.ErrorIs(t, json.Unmarshal([]byte(doc), &e), ErrInvalidEnvelope, doc)
+		assert.Equal(t, 1, e.Unwrap())
+	}
+
+	var e Envelope[int]
+	assert.Error(t, json.Unmarshal([]byte(`{"some":"x"}`), &e))
+	assert.Error(t, json.Unmarshal([]byte(`[]`), &e))
+}
+
+func TestEnvelope_RegisteredErrors(t *testing.T) {
+	in := envelopeReply{User: Envelope[string]{None[string](errEnvelopeTest)}}
+	out, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user":{"none":{"error":"envelope test: user not found"}}}`, string(out))
+
+	var back envelopeReply
+	require.NoError(t, json.Unmarshal(out, &back))
+	assert.ErrorIs(t, back.User.Error(), errEnvelopeTest)
+	assert.True(t, back.Count.IsNone())
+
+	nilValue := Envelope[*int]{Some[*int](nil)}
+	out, err = json.Marshal(nilValue)
+	require.NoError(t, err)
+
+	var decoded Envelope[*int]
+	require.NoError(t, json.Unmarshal(out, &decoded))
+	assert.ErrorIs(t, decoded.Error(), ErrNilValue)
+}
+
+func TestEnvelope_EmbedsOption(t *testing.T) {
+	e := Envelope[int]{Some(1)}
+	assert.True(t, e.IsSome())
+	assert.Equal(t, 1, e.Option.Unwrap())
+	assert.Equal(t, 2, Map(e.Option, func(n int) int { return n * 2 }).Unwrap())
+}
@@ -0,0 +1,74 @@
+package option
+
+import (
+  "flag"
+  "reflect"
+)
+
+// Flag is a flag.Value that records whether the flag was given on the command
+// line. It embeds the Option holding the parsed value, which is None until
+// Set is called, so after parsing it tells "the user passed the default" from
+// "the user did not pass the flag" and can feed straight into UnwrapOr.
+//
+// Values are parsed with the same rules as Parse. Flags of a boolean type can
+// be given without a value, as in -verbose.
+//
+// Example:
+//
+//	port := option.NewFlag[int](flag.CommandLine, "port", "listen port")
+//	flag.Parse()
+//
+//	addr := fmt.Sprintf(":%d", port.UnwrapOr(8080))
+//	if port.IsSome() {
+//		log.Printf("port set on the command line")
+//	}
+type Flag[T any] struct {
+  Option[T]
+}
+
+// NewFlag defines a flag with the specified name and usage on fs and returns
+// it. The flag is None until it is given on the command line.
+func NewFlag[T any](fs *flag.FlagSet, name, usage string) *Flag[T] {
+  f := &Flag[T]{}
+  fs.Var(f, name, usage)
+  return f
+}
+
+var (
+  _ flag.Getter = (*Flag[int])(nil)
+)
+
+// String implements flag.Value. It returns the text form of the value, or the
+// empty string if the flag was not set.
+func (f *Flag[T]) String() string {
+  if f == nil || !f.ok {
+    return ""
+  }
+  text, err := marshalText(reflect.ValueOf(&f.some).Elem())
+  if err != nil {
+    return ""
+  }
+  return string(text)
+}
+
+// Set implements flag.Value. It parses s and makes the flag Some, even if s is
+// empty. On error the flag is left unchanged.
+func (f *Flag[T]) Set(s string) error {
+  var value T
+  if err := unmarshalText([]byte(s), reflect.ValueOf(&value).Elem()); err != nil {
+    return err
+  }
+  f.Option = Some(value)
+  return nil
+}
+
+// Get implements flag.Getter. It returns the flag's Option[T].
+func (f *Flag[T]) Get() any {
+  return f.Option
+}
+
+// IsBoolFlag reports whether T is a boolean type, which lets the flag be given
+// without a value.
+func (f *Flag[T]) IsBoolFlag() bool {
+  return reflect.TypeFor[T]().Kind() == reflect.Bool
+}
@@ -0,0 +1,86 @@
+package option
+
+import (
+	"bytes"
+	"flag"
+	"net/netip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&bytes.Buffer{})
+	return fs
+}
+
+func TestFlag_Set(t *testing.T) {
+	fs := newTestFlagSet()
+	port := NewFlag[int](fs, "port", "listen port")
+	host := NewFlag[string](fs, "host", "listen host")
+	addr := NewFlag[netip.Addr](fs, "addr", "bind address")
+	verbose := NewFlag[bool](fs, "verbose", "verbose output")
+	ratio := NewFlag[float64](fs, "ratio", "sample ratio")
+
+	require.NoError(t, fs.Parse([]string{"-port", "8080", "-host=", "-addr", "127.0.0.1", "-verbose"}))
+
+	assert.Equal(t, 8080, port.Unwrap())
+	assert.True(t, host.IsSome())
+	assert.Equal(t, "", host.Unwrap())
+	assert.Equal(t, netip.MustParseAddr("127.0.0.1"), addr.Unwrap())
+	assert.True(t, verbose.Unwrap())
+	assert.True(t, ratio.IsNone())
+	assert.Equal(t, 0.1, ratio.UnwrapOr(0.1))
+}
+
+func TestFlag_DefaultVsUnset(t *testing.T) {
+	fs := newTestFlagSet()
+	port := NewFlag[int](fs, "port", "listen port")
+	require.NoError(t, fs.Parse([]string{"-port", "8080"}))
+	assert.True(t, port.IsSome())
+	assert.Equal(t, 8080, port.UnwrapOr(8080))
+
+	fs = newTestFlagSet()
+	port = NewFlag[int](fs, "port", "listen port")
+	require.NoError(t, fs.Parse(nil))
+	assert.True(t, port.IsNone())
+	assert.Equal(t, 8080, port.UnwrapOr(8080))
+}
+
+func TestFlag_Invalid(t *testing.T) {
+	fs := newTestFlagSet()
+	port := NewFlag[int](fs, "port", "listen port")
+	err := fs.Parse([]string{"-port", "eighty"})
+	require.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), "-port"))
+	assert.True(t, port.IsNone())
+}
+
+func TestFlag_Var(t *testing.T) {
+	fs := newTestFlagSet()
+	var lvl Flag[level]
+	fs.Var(&lvl, "level", "log level")
+	require.NoError(t, fs.Parse([]string{"-level=3"}))
+	assert.Equal(t, "3", lvl.String())
+	assert.Equal(t, Some[level](3), fs.Lookup("level").Value.(flag.Getter).Get())
+}
+
+func TestFlag_String(t *testing.T) {
+	var f *Flag[int]
+	assert.Equal(t, "", f.String())
+	assert.Equal(t, "", (&Flag[int]{}).String())
+	assert.Equal(t, "42", (&Flag[int]{Some(42)}).String())
+}
+
+func TestFlag_PrintDefaults(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var buf bytes.Buffer
+	fs.SetOutput(&buf)
+	NewFlag[int](fs, "port", "listen `port`")
+	NewFlag[bool](fs, "verbose", "verbose output")
+	fs.PrintDefaults()
+	assert.Equal(t, "  -port port\n    \tlisten port\n  -verbose\n    \tverbose output\n", buf.String())
+}
@@ -0,0 +1,65 @@
+package option
+
+import (
+  "fmt"
+  "reflect"
+  "strconv"
+)
+
+// String implements fmt.Stringer. Some options are rendered as "Some(value)",
+// None options as "None" or "None(reason)" when they carry an error.
+//
+// Example:
+//
+//	option.Some(42).String()                           // "Some(42)"
+//	option.None[int](errors.New("not found")).String() // "None(not found)"
+//	option.None[int](nil).String()                     // "None"
+func (o Option[T]) String() string {
+  return fmt.Sprintf("%v", o)
+}
+
+// GoString implements fmt.GoStringer and is used by the %#v verb. The result
+// is Go syntax that rebuilds an equivalent option.
+//
+// Example:
+//
+//	option.Some(42).GoString()                           // "option.Some[int](42)"
+//	option.None[int](errors.New("not found")).GoString() // `option.None[int](errors.New("not found"))`
+func (o Option[T]) GoString() string {
+  typ := reflect.TypeFor[T]().String()
+  if o.ok {
+    return fmt.Sprintf("option.Some[%s](%#v)", typ, o.some)
+  }
+  if o.err == nil {
+    return fmt.Sprintf("option.None[%s](nil)", typ)
+  }
+  return fmt.Sprintf("option.None[%s](errors.New(%s))", typ, strconv.Quote(o.err.Error()))
+}
+
+// Format implements fmt.Formatter.
+//
+// The verb and its flags are applied to the contained value of a Some option,
+// so %5.2f prints Some( 3.14) and %+v prints Some({Name:gopher}). A None option
+// prints "None", followed by its error formatted with the same verb when it
+// carries one; %+v therefore keeps any extended error detail, and %q quotes
+// the error message. The %#v verb prints the result of GoString.
+func (o Option[T]) Format(f fmt.State, verb rune) {
+  if verb == 'v' && f.Flag('#') {
+    fmt.Fprint(f, o.GoString())
+    return
+  }
+  if o.ok {
+    fmt.Fprintf(f, "Some("+fmt.FormatString(f, verb)+")", o.some)
+    return
+  }
+  if o.err == nil {
+    fmt.Fprint(f, "None")
+    return
+  }
+  switch verb {
+  case 'v', 's', 'q':
+    fmt.Fprintf(f, "None("+fmt.FormatString(f, verb)+")", o.err)
+  default:
+    fmt.Fprintf(f, "None(%v)", o.err)
+  }
+}
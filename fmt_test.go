@@ -0,0 +1,80 @@
+package option
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fmtStruct struct {
+	Name string
+	Age  int
+}
+
+func TestOption_String(t *testing.T) {
+	assert.Equal(t, "Some(42)", Some(42).String())
+	assert.Equal(t, "Some(hello)", Some("hello").String())
+	assert.Equal(t, "None", None[int](nil).String())
+	assert.Equal(t, "None(not found)", None[int](errors.New("not found")).String())
+}
+
+func TestOption_GoString(t *testing.T) {
+	assert.Equal(t, "option.Some[int](42)", Some(42).GoString())
+	assert.Equal(t, `option.Some[string]("hello")`, Some("hello").GoString())
+	assert.Equal(t, "option.None[int](nil)", None[int](nil).GoString())
+	assert.Equal(t, `option.None[int](errors.New("not found"))`, None[int](errors.New("not found")).GoString())
+}
+
+func TestOption_Format(t *testing.T) {
+	err := errors.New("not found")
+	tests := []struct {
+		format string
+		value  any
+		want   string
+	}{
+		{"%v", Some(42), "Some(42)"},
+		{"%v", None[int](nil), "None"},
+		{"%v", None[int](err), "None(not found)"},
+		{"%s", Some("hello"), "Some(hello)"},
+		{"%d", Some(42), "Some(42)"},
+		{"%05d", Some(42), "Some(00042)"},
+		{"%x", Some(255), "Some(ff)"},
+		{"%5.2f", Some(3.14159), "Some( 3.14)"},
+		{"%d", None[int](err), "None(not found)"},
+		{"%+v", Some(fmtStruct{"gopher", 13}), "Some({Name:gopher Age:13})"},
+		{"%+v", None[fmtStruct](err), "None(not found)"},
+		{"%#v", Some(42), "option.Some[int](42)"},
+		{"%#v", Some(fmtStruct{"gopher", 13}), `option.Some[option.fmtStruct](option.fmtStruct{Name:"gopher", Age:13})`},
+		{"%#v", None[int](err), `option.None[int](errors.New("not found"))`},
+		{"%q", Some("hello"), `Some("hello")`},
+		{"%q", None[string](err), `None("not found")`},
+		{"%q", None[string](nil), "None"},
+		{"%v", Some(Some(1)), "Some(Some(1))"},
+		{"%v", []Option[int]{Some(1), None[int](nil)}, "[Some(1) None]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format+" "+tt.want, func(t *testing.T) {
+			assert.Equal(t, tt.want, fmt.Sprintf(tt.format, tt.value))
+		})
+	}
+}
+
+func BenchmarkOption_String(b *testing.B) {
+	some := Some(42)
+	for i := 0; i < b.N; i++ {
+		_ = some.String()
+	}
+}
+
+func ExampleOption_String() {
+	fmt.Println(Some(42))
+	fmt.Println(None[int](errors.New("not found")))
+	fmt.Printf("%#v\n", Some("hello"))
+	// Output:
+	// Some(42)
+	// None(not found)
+	// option.Some[string]("hello")
+}
@@ -0,0 +1,43 @@
+package option
+
+import (
+  "encoding/json"
+)
+
+// MarshalJSON implements json.Marshaler. A Some option is encoded as its
+// contained value and a None option as null; the error of a None option is
+// not encoded, see Envelope for a format that keeps it. Use the omitzero tag
+// option to leave None fields out entirely.
+//
+// Example:
+//
+//	type User struct {
+//		Name  string                `json:"name"`
+//		Email option.Option[string] `json:"email"`
+//	}
+//
+//	out, _ := json.Marshal(User{Name: "gopher"})
+//	// {"name":"gopher","email":null}
+func (o Option[T]) MarshalJSON() ([]byte, error) {
+  if !o.ok {
+    return []byte("null"), nil
+  }
+  return json.Marshal(o.some)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null decodes to None with a
+// nil error, and a key missing from the object leaves the field at its zero
+// value, which is None as well. Any other value is decoded into the
+// contained value. On error the option is left unchanged.
+func (o *Option[T]) UnmarshalJSON(data []byte) error {
+  if string(data) == "null" {
+    *o = None[T](nil)
+    return nil
+  }
+  var value T
+  if err := json.Unmarshal(data, &value); err != nil {
+    return err
+  }
+  *o = Some(value)
+  return nil
+}
@@ -0,0 +1,59 @@
+package option
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonUser struct {
+	Name  string           `json:"name"`
+	Email Option[string]   `json:"email"`
+	Age   Option[int]      `json:"age,omitzero"`
+	Tags  Option[[]string] `json:"tags,omitzero"`
+}
+
+func TestOption_MarshalJSON(t *testing.T) {
+	out, err := json.Marshal(jsonUser{Name: "gopher", Age: Some(13)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"gopher","email":null,"age":13}`, string(out))
+
+	out, err = json.Marshal(jsonUser{Email: None[string](errors.New("hidden")), Tags: Some([]string{"a"})})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"","email":null,"tags":["a"]}`, string(out))
+}
+
+func TestOption_UnmarshalJSON(t *testing.T) {
+	var u jsonUser
+	require.NoError(t, json.Unmarshal([]byte(`{"name":"gopher","email":"g@example.com","tags":["x"]}`), &u))
+	assert.Equal(t, "g@example.com", u.Email.Unwrap())
+	assert.Equal(t, []string{"x"}, u.Tags.Unwrap())
+	assert.True(t, u.Age.IsNone())
+
+	u = jsonUser{Email: Some("previous")}
+	require.NoError(t, json.Unmarshal([]byte(`{"email":null}`), &u))
+	assert.True(t, u.Email.IsNone())
+	assert.Nil(t, u.Email.Error())
+}
+
+func TestOption_UnmarshalJSON_Error(t *testing.T) {
+	var u jsonUser
+	err := json.Unmarshal([]byte(`{"age":"old"}`), &u)
+	var typeErr *json.UnmarshalTypeError
+	assert.ErrorAs(t, err, &typeErr)
+}
+
+func TestOption_JSON_MapValues(t *testing.T) {
+	in := map[string]Option[int]{"a": Some(1), "b": None[int](nil)}
+	out, err := json.Marshal(in)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a":1,"b":null}`, string(out))
+
+	var back map[string]Option[int]
+	require.NoError(t, json.Unmarshal(out, &back))
+	assert.True(t, Equal(in["a"], back["a"]))
+	assert.True(t, back["b"].IsNone())
+}
@@ -0,0 +1,181 @@
+package option
+
+import (
+  "errors"
+  "iter"
+)
+
+var (
+  ErrKeyNotFound = errors.New("option: key not found")
+)
+
+// OptionMap is a map wrapper whose lookups return Options instead of the
+// comma-ok idiom. Its Entry API is modelled on Rust's HashMap entry API.
+//
+// The zero value is an empty map ready to use. An OptionMap is not safe for
+// concurrent use without external synchronization.
+//
+// Example:
+//
+//	cache := option.NewOptionMap[string, int]()
+//	cache.Insert("answer", 42)
+//
+//	v := cache.Get("answer")   // Some(42)
+//	w := cache.Get("question") // None with ErrKeyNotFound
+type OptionMap[K comparable, V any] struct {
+  m map[K]V
+}
+
+// NewOptionMap creates an empty OptionMap.
+func NewOptionMap[K comparable, V any]() *OptionMap[K, V] {
+  return &OptionMap[K, V]{m: make(map[K]V)}
+}
+
+// Get returns Some with the value stored under key, or None with
+// ErrKeyNotFound if the key is absent. A stored nil value is returned as Some.
+//
+// Example:
+//
+//	m := option.NewOptionMap[string, int]()
+//	m.Insert("a", 1)
+//	m.Get("a").UnwrapOr(0) // Returns 1
+//	m.Get("b").UnwrapOr(0) // Returns 0
+func (m *OptionMap[K, V]) Get(key K) Option[V] {
+  v, ok := m.m[key]
+  if !ok {
+    return None[V](ErrKeyNotFound)
+  }
+  // Key presence decides Some, so a stored nil slice, map or pointer is
+  // still Some rather than None(ErrNilValue).
+  return Option[V]{ok: true, some: v}
+}
+
+// ContainsKey reports whether the map holds a value for key.
+func (m *OptionMap[K, V]) ContainsKey(key K) bool {
+  _, ok := m.m[key]
+  return ok
+}
+
+// Insert stores value under key and returns the previous value, if any.
+//
+// Example:
+//
+//	m := option.NewOptionMap[string, int]()
+//	m.Insert("a", 1) // None, nothing was stored before
+//	m.Insert("a", 2) // Some(1)
+func (m *OptionMap[K, V]) Insert(key K, value V) Option[V] {
+  old := m.Get(key)
+  if m.m == nil {
+    m.m = make(map[K]V)
+  }
+  m.m[key] = value
+  return old
+}
+
+// Remove deletes key from the map and returns the value it held, if any.
+func (m *OptionMap[K, V]) Remove(key K) Option[V] {
+  old := m.Get(key)
+  delete(m.m, key)
+  return old
+}
+
+// Len returns the number of entries in the map.
+func (m *OptionMap[K, V]) Len() int {
+  return len(m.m)
+}
+
+// All returns an iterator over the key-value pairs of the map.
+// As with a built-in map, the iteration order is not specified.
+func (m *OptionMap[K, V]) All() iter.Seq2[K, V] {
+  return func(yield func(K, V) bool) {
+    for k, v := range m.m {
+      if !yield(k, v) {
+        return
+      }
+    }
+  }
+}
+
+// Entry returns the entry for key, which can be used to inspect or update the
+// slot in place without repeating the lookup logic.
+//
+// Example:
+//
+//	counts := option.NewOptionMap[string, int]()
+//	for _, word := range words {
+//		counts.Entry(word).AndModify(func(n *int) { *n++ }).OrInsert(1)
+//	}
+func (m *OptionMap[K, V]) Entry(key K) Entry[K, V] {
+  return Entry[K, V]{m: m, key: key}
+}
+
+// Entry is a view into a single slot of an OptionMap, which may be occupied
+// or vacant. It is obtained from OptionMap.Entry.
+type Entry[K comparable, V any] struct {
+  m   *OptionMap[K, V]
+  key K
+}
+
+// Key returns the key of the entry.
+func (e Entry[K, V]) Key() K {
+  return e.key
+}
+
+// Get returns Some with the value of an occupied entry, or None with
+// ErrKeyNotFound if the entry is vacant.
+func (e Entry[K, V]) Get() Option[V] {
+  return e.m.Get(e.key)
+}
+
+// OrInsert stores def if the entry is vacant and returns the value of the entry.
+//
+// Example:
+//
+//	registry := option.NewOptionMap[string, []string]()
+//	registry.Entry("admins").OrInsert(nil) // Returns nil, "admins" is now present
+func (e Entry[K, V]) OrInsert(def V) V {
+  return e.OrInsertWith(func() V { return def })
+}
+
+// OrInsertWith calls f and stores its result if the entry is vacant, and
+// returns the value of the entry. f is not called for occupied entries,
+// which makes it suitable for expensive "get or create" logic.
+//
+// Example:
+//
+//	conn := pool.Entry(addr).OrInsertWith(func() *Conn { return dial(addr) })
+func (e Entry[K, V]) OrInsertWith(f func() V) V {
+  if v, ok := e.m.m[e.key]; ok {
+    return v
+  }
+  v := f()
+  e.m.Insert(e.key, v)
+  return v
+}
+
+// OrDefault stores the zero value of V if the entry is vacant and returns the
+// value of the entry.
+func (e Entry[K, V]) OrDefault() V {
+  var zero V
+  return e.OrInsert(zero)
+}
+
+// AndModify calls f with a pointer to the value of an occupied entry and
+// stores the modified value back. Vacant entries are left untouched.
+// It returns the entry so it can be chained with OrInsert.
+//
+// Example:
+//
+//	m.Entry("hits").AndModify(func(n *int) { *n++ }).OrInsert(1)
+func (e Entry[K, V]) AndModify(f func(*V)) Entry[K, V] {
+  if v, ok := e.m.m[e.key]; ok {
+    f(&v)
+    e.m.m[e.key] = v
+  }
+  return e
+}
+
+// Remove deletes the entry from the map and returns the value it held, if any.
+func (e Entry[K, V]) Remove() Option[V] {
+  return e.m.Remove(e.key)
+}
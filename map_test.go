@@ -0,0 +1,165 @@
+package option
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOptionMap_Get(t *testing.T) {
+	m := NewOptionMap[string, int]()
+	m.Insert("a", 1)
+
+	assert.Equal(t, 1, m.Get("a").Unwrap())
+	assert.True(t, m.Get("b").IsNone())
+	assert.ErrorIs(t, m.Get("b").Error(), ErrKeyNotFound)
+}
+
+func TestOptionMap_NilValue(t *testing.T) {
+	m := NewOptionMap[string, []string]()
+	assert.Nil(t, m.Entry("admins").OrInsert(nil))
+	assert.True(t, m.ContainsKey("admins"))
+
+	got := m.Get("admins")
+	assert.True(t, got.IsSome())
+	assert.Nil(t, got.Unwrap())
+	assert.True(t, m.Entry("admins").Get().IsSome())
+
+	old := m.Insert("admins", []string{"root"})
+	assert.True(t, old.IsSome())
+	assert.Nil(t, old.Unwrap())
+
+	m.Insert("admins", nil)
+	removed := m.Entry("admins").Remove()
+	assert.True(t, removed.IsSome())
+	assert.False(t, m.ContainsKey("admins"))
+	assert.ErrorIs(t, m.Remove("admins").Error(), ErrKeyNotFound)
+}
+
+func TestOptionMap_ZeroValue(t *testing.T) {
+	var m OptionMap[string, int]
+	assert.True(t, m.Get("a").IsNone())
+	assert.True(t, m.Remove("a").IsNone())
+	assert.Equal(t, 0, m.Len())
+
+	m.Insert("a", 1)
+	assert.Equal(t, 1, m.Get("a").Unwrap())
+}
+
+func TestOptionMap_Insert(t *testing.T) {
+	m := NewOptionMap[string, int]()
+
+	old := m.Insert("a", 1)
+	assert.True(t, old.IsNone())
+
+	old = m.Insert("a", 2)
+	assert.Equal(t, 1, old.Unwrap())
+	assert.Equal(t, 2, m.Get("a").Unwrap())
+	assert.Equal(t, 1, m.Len())
+}
+
+func TestOptionMap_Remove(t *testing.T) {
+	m := NewOptionMap[string, int]()
+	m.Insert("a", 1)
+
+	assert.Equal(t, 1, m.Remove("a").Unwrap())
+	assert.False(t, m.ContainsKey("a"))
+	assert.ErrorIs(t, m.Remove("a").Error(), ErrKeyNotFound)
+}
+
+func TestOptionMap_All(t *testing.T) {
+	m := NewOptionMap[string, int]()
+	m.Insert("a", 1)
+	m.Insert("b", 2)
+
+	got := map[string]int{}
+	for k, v := range m.All() {
+		got[k] = v
+	}
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, got)
+}
+
+func TestEntry_OrInsert(t *testing.T) {
+	m := NewOptionMap[string, int]()
+
+	assert.Equal(t, 1, m.Entry("a").OrInsert(1))
+	assert.Equal(t, 1, m.Entry("a").OrInsert(2))
+	assert.Equal(t, 1, m.Get("a").Unwrap())
+}
+
+func TestEntry_OrInsertWith(t *testing.T) {
+	m := NewOptionMap[string, int]()
+	calls := 0
+	create := func() int {
+		calls++
+		return 42
+	}
+
+	assert.Equal(t, 42, m.Entry("a").OrInsertWith(create))
+	assert.Equal(t, 42, m.Entry("a").OrInsertWith(create))
+	assert.Equal(t, 1, calls)
+}
+
+func TestEntry_OrDefault(t *testing.T) {
+	m := NewOptionMap[string, []string]()
+
+	assert.Nil(t, m.Entry("a").OrDefault())
+	assert.True(t, m.ContainsKey("a"))
+}
+
+func TestEntry_AndModify(t *testing.T) {
+	m := NewOptionMap[string, int]()
+
+	for range 3 {
+		m.Entry("hits").AndModify(func(n *int) { *n++ }).OrInsert(1)
+	}
+	assert.Equal(t, 3, m.Get("hits").Unwrap())
+
+	m.Entry("missing").AndModify(func(n *int) { *n++ })
+	assert.False(t, m.ContainsKey("missing"))
+}
+
+func TestEntry_Get(t *testing.T) {
+	m := NewOptionMap[string, int]()
+	e := m.Entry("a")
+
+	assert.Equal(t, "a", e.Key())
+	assert.True(t, e.Get().IsNone())
+
+	e.OrInsert(7)
+	assert.Equal(t, 7, e.Get().Unwrap())
+}
+
+func TestEntry_Remove(t *testing.T) {
+	m := NewOptionMap[string, int]()
+	m.Insert("a", 1)
+
+	assert.Equal(t, 1, m.Entry("a").Remove().Unwrap())
+	assert.True(t, m.Entry("a").Remove().IsNone())
+	assert.Equal(t, 0, m.Len())
+}
+
+func BenchmarkOptionMap_Get(b *testing.B) {
+	m := NewOptionMap[int, int]()
+	m.Insert(1, 1)
+	for i := 0; i < b.N; i++ {
+		m.Get(1)
+	}
+}
+
+func BenchmarkEntry_AndModify_OrInsert(b *testing.B) {
+	m := NewOptionMap[int, int]()
+	for i := 0; i < b.N; i++ {
+		m.Entry(i % 16).AndModify(func(n *int) { *n++ }).OrInsert(1)
+	}
+}
+
+func ExampleOptionMap_Entry() {
+	counts := NewOptionMap[string, int]()
+	for _, word := range []string{"a", "b", "a"} {
+		counts.Entry(word).AndModify(func(n *int) { *n++ }).OrInsert(1)
+	}
+	fmt.Println(counts.Get("a").UnwrapOr(0), counts.Get("c").UnwrapOr(0))
+	// Output: 2 0
+}
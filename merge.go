@@ -0,0 +1,264 @@
+package option
+
+import (
+  "errors"
+  "fmt"
+  "reflect"
+  "strings"
+  "sync"
+)
+
+var (
+  ErrRequired = errors.New("option: required field is None")
+)
+
+// MissingFieldsError is returned by Resolve when fields tagged
+// `option:"required"` are still None. It matches ErrRequired with errors.Is.
+type MissingFieldsError struct {
+  // Fields holds the dotted paths of the missing fields, such as "DB.Host".
+  Fields []string
+}
+
+func (e *MissingFieldsError) Error() string {
+  return "option: missing required fields: " + strings.Join(e.Fields, ", ")
+}
+
+func (e *MissingFieldsError) Is(target error) bool {
+  return target == ErrRequired
+}
+
+// mergeKind tells how a struct field is merged.
+type mergeKind uint8
+
+const (
+  kindOption mergeKind = iota
+  kindNullable
+  kindStruct
+  kindOther
+)
+
+// mergeField is one step of a mergePlan.
+type mergeField struct {
+  name     string
+  index    int
+  kind     mergeKind
+  required bool
+  nested   *mergePlan
+}
+
+// mergePlan lists the exported fields of a struct type and how to merge them.
+type mergePlan struct {
+  fields []mergeField
+}
+
+var (
+  mergePlanCache     sync.Map
+  optionalType       = reflect.TypeFor[Optional]()
+  nullableSetterType = reflect.TypeFor[nullableSetter]()
+)
+
+// mergePlanOf returns the cached merge plan for the struct type t.
+func mergePlanOf(t reflect.Type) *mergePlan {
+  if cached, ok := mergePlanCache.Load(t); ok {
+    return cached.(*mergePlan)
+  }
+
+  plan := &mergePlan{}
+  for i := 0; i < t.NumField(); i++ {
+    sf := t.Field(i)
+    if !sf.IsExported() {
+      continue
+    }
+    f := mergeField{name: sf.Name, index: i, required: hasTagOption(sf.Tag.Get("option"), "required")}
+    switch {
+    case sf.Type.Implements(optionalType):
+      f.kind = kindOption
+    case reflect.PointerTo(sf.Type).Implements(nullableSetterType):
+      f.kind = kindNullable
+    case mergeable(sf.Type):
+      f.kind = kindStruct
+      f.nested = mergePlanOf(sf.Type)
+    default:
+      f.kind = kindOther
+    }
+    plan.fields = append(plan.fields, f)
+  }
+
+  cached, _ := mergePlanCache.LoadOrStore(t, plan)
+  return cached.(*mergePlan)
+}
+
+// mergeable reports whether fields of type t are merged field by field. Only
+// plain structs with exported fields are; opaque value types such as time.Time
+// or netip.Addr are overridden as a whole.
+func mergeable(t reflect.Type) bool {
+  if t.Kind() != reflect.Struct {
+    return false
+  }
+  p := reflect.PointerTo(t)
+  if p.Implements(textUnmarshalerType) || p.Implements(jsonUnmarshalerType) {
+    return false
+  }
+  for i := 0; i < t.NumField(); i++ {
+    if t.Field(i).IsExported() {
+      return true
+    }
+  }
+  return false
+}
+
+// hasTagOption reports whether the comma-separated tag contains opt.
+func hasTagOption(tag, opt string) bool {
+  for tag != "" {
+    var name string
+    name, tag, _ = strings.Cut(tag, ",")
+    if strings.TrimSpace(name) == opt {
+      return true
+    }
+  }
+  return false
+}
+
+// apply merges the fields of src over dst.
+func (p *mergePlan) apply(dst, src reflect.Value) {
+  for _, f := range p.fields {
+    d, s := dst.Field(f.index), src.Field(f.index)
+    switch f.kind {
+    case kindOption:
+      if s.Interface().(Optional).IsSome() {
+        d.Set(s)
+      }
+    case kindNullable:
+      if !s.IsZero() {
+        d.Set(s)
+      }
+    case kindStruct:
+      f.nested.apply(d, s)
+    default:
+      if !s.IsZero() {
+        d.Set(s)
+      }
+    }
+  }
+}
+
+// Merge layers structs whose fields are Options, such as configuration read
+// from defaults, files, the environment and flags. Layers are applied in
+// order, so for every Option field the last layer holding Some wins and None
+// leaves earlier values alone. Nested structs are merged field by field.
+// Nullable fields are overridden unless absent, and any other field is
+// overridden by non-zero values.
+//
+// Merge panics if S is not a struct type. The per-type merge plan is computed
+// once and cached.
+//
+// Example:
+//
+//	type Settings struct {
+//		Host option.Option[string]
+//		Port option.Option[int]
+//	}
+//
+//	merged := option.Merge(defaults, fromFile, fromEnv, fromFlags)
+func Merge[S any](layers ...S) S {
+  var out S
+  v := reflect.ValueOf(&out).Elem()
+  if v.Kind() != reflect.Struct {
+    panic(fmt.Sprintf("option: Merge called with non-struct type %s", v.Type()))
+  }
+
+  plan := mergePlanOf(v.Type())
+  for i := range layers {
+    plan.apply(v, reflect.ValueOf(&layers[i]).Elem())
+  }
+  return out
+}
+
+// Resolve converts a struct of Options, typically the result of Merge, into
+// the plain struct P. Fields are matched by name: an Option[T] field fills a
+// T field with its value, or with the zero value when None, nested structs
+// are resolved recursively and any other field is copied as is.
+//
+// Option fields of S tagged `option:"required"` must be Some. Resolve checks
+// all of them and reports those that are None in a *MissingFieldsError.
+// Fields of S without a counterpart in P are ignored; a counterpart of an
+// incompatible type is an error.
+//
+// Example:
+//
+//	type Settings struct {
+//		Host option.Option[string] `option:"required"`
+//		Port option.Option[int]
+//	}
+//
+//	type Config struct {
+//		Host string
+//		Port int
+//	}
+//
+//	cfg, err := option.Resolve[Config](option.Merge(defaults, fromFile))
+//	// err lists "Host" if no layer set it
+func Resolve[P, S any](src S) (P, error) {
+  var out P
+  dst := reflect.ValueOf(&out).Elem()
+  sv := reflect.ValueOf(src)
+  if dst.Kind() != reflect.Struct || sv.Kind() != reflect.Struct {
+    return out, fmt.Errorf("option: Resolve needs struct types, got %s and %s", dst.Type(), sv.Type())
+  }
+
+  var missing []string
+  if err := resolveStruct(dst, sv, "", &missing); err != nil {
+    return out, err
+  }
+  if len(missing) > 0 {
+    return out, &MissingFieldsError{Fields: missing}
+  }
+  return out, nil
+}
+
+// resolveStruct copies the fields of src into dst, collecting the paths of
+// required fields that are None.
+func resolveStruct(dst, src reflect.Value, prefix string, missing *[]string) error {
+  for _, f := range mergePlanOf(src.Type()).fields {
+    path := prefix + f.name
+    s := src.Field(f.index)
+    d := dst.FieldByName(f.name)
+
+    if f.kind == kindOption {
+      opt := s.Interface().(Optional)
+      value, ok := opt.Interface()
+      if !ok && f.required {
+        *missing = append(*missing, path)
+      }
+      if !d.IsValid() || !d.CanSet() {
+        continue
+      }
+      switch {
+      case d.Type() == s.Type():
+        d.Set(s)
+      case opt.ElemType().AssignableTo(d.Type()):
+        if ok {
+          d.Set(reflect.ValueOf(value))
+        }
+      default:
+        return fmt.Errorf("option: cannot resolve %s of type %s into %s", path, s.Type(), d.Type())
+      }
+      continue
+    }
+
+    if !d.IsValid() || !d.CanSet() {
+      continue
+    }
+    switch {
+    case f.kind == kindStruct && d.Kind() == reflect.Struct && d.Type() != s.Type():
+      if err := resolveStruct(d, s, path+".", missing); err != nil {
+        return err
+      }
+    case s.Type().AssignableTo(d.Type()):
+      d.Set(s)
+    default:
+      return fmt.Errorf("option: cannot resolve %s of type %s into %s", path, s.Type(), d.Type())
+    }
+  }
+  return nil
+}
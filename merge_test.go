@@ -0,0 +1,140 @@
+package option
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type dbSettings struct {
+	Host Option[string] `option:"required"`
+	Port Option[int]
+}
+
+type settings struct {
+	Name    Option[string] `option:"required"`
+	Timeout Option[time.Duration]
+	Debug   Option[bool]
+	Tags    []string
+	Level   Nullable[int]
+	DB      dbSettings
+	Replica Option[dbSettings]
+	At      time.Time
+	secret  string
+}
+
+type config struct {
+	Name    string
+	Timeout time.Duration
+	Debug   bool
+	Tags    []string
+	DB      dbConfig
+	Replica Option[dbSettings]
+	Extra   string
+}
+
+type dbConfig struct {
+	Host string
+	Port int
+}
+
+func TestMerge(t *testing.T) {
+	defaults := settings{
+		Name:    Some("app"),
+		Timeout: Some(time.Second),
+		Debug:   Some(false),
+		DB:      dbSettings{Host: Some("localhost"), Port: Some(5432)},
+	}
+	file := settings{
+		Timeout: Some(5 * time.Second),
+		Tags:    []string{"file"},
+		Level:   Value(2),
+		DB:      dbSettings{Host: Some("db.internal")},
+	}
+	env := settings{
+		Debug:   Some(true),
+		Level:   Null[int](),
+		Replica: Some(dbSettings{Port: Some(6543)}),
+		secret:  "ignored",
+	}
+	file.At = time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	merged := Merge(defaults, file, env)
+	assert.Equal(t, "app", merged.Name.Unwrap())
+	assert.Equal(t, 5*time.Second, merged.Timeout.Unwrap())
+	assert.True(t, merged.Debug.Unwrap())
+	assert.Equal(t, []string{"file"}, merged.Tags)
+	assert.True(t, merged.Level.IsNull())
+	assert.Equal(t, "db.internal", merged.DB.Host.Unwrap())
+	assert.Equal(t, 5432, merged.DB.Port.Unwrap())
+	assert.True(t, merged.Replica.Unwrap().Host.IsNone())
+	assert.Equal(t, file.At, merged.At)
+	assert.Empty(t, merged.secret)
+}
+
+func TestMerge_NoLayers(t *testing.T) {
+	merged := Merge[settings]()
+	assert.True(t, merged.Name.IsNone())
+}
+
+func TestMerge_DoesNotModifyLayers(t *testing.T) {
+	base := settings{Name: Some("base")}
+	Merge(base, settings{Name: Some("override")})
+	assert.Equal(t, "base", base.Name.Unwrap())
+}
+
+func TestMerge_NonStruct(t *testing.T) {
+	assert.Panics(t, func() { Merge(1, 2) })
+}
+
+func TestResolve(t *testing.T) {
+	merged := Merge(
+		settings{Name: Some("app"), Timeout: Some(time.Second), DB: dbSettings{Host: Some("localhost")}},
+		settings{Tags: []string{"x"}, Replica: Some(dbSettings{})},
+	)
+
+	cfg, err := Resolve[config](merged)
+	require.NoError(t, err)
+	assert.Equal(t, config{
+		Name:    "app",
+		Timeout: time.Second,
+		Tags:    []string{"x"},
+		DB:      dbConfig{Host: "localhost"},
+		Replica: Some(dbSettings{}),
+	}, cfg)
+}
+
+func TestResolve_MissingRequired(t *testing.T) {
+	_, err := Resolve[config](settings{Timeout: Some(time.Second)})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrRequired)
+
+	var missing *MissingFieldsError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{"Name", "DB.Host"}, missing.Fields)
+	assert.Equal(t, "option: missing required fields: Name, DB.Host", err.Error())
+}
+
+func TestResolve_TypeMismatch(t *testing.T) {
+	type wrong struct {
+		Name int
+	}
+	_, err := Resolve[wrong](settings{Name: Some("app")})
+	assert.Error(t, err)
+
+	_, err = Resolve[int](settings{})
+	assert.Error(t, err)
+}
+
+func BenchmarkMerge(b *testing.B) {
+	layers := []settings{
+		{Name: Some("app"), DB: dbSettings{Host: Some("localhost")}},
+		{Timeout: Some(time.Second)},
+		{Debug: Some(true)},
+	}
+	for i := 0; i < b.N; i++ {
+		Merge(layers...)
+	}
+}
@@ -0,0 +1,323 @@
+package option
+
+import (
+  "bytes"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "reflect"
+  "strings"
+  "sync"
+)
+
+var (
+  ErrInvalidPatch = errors.New("option: invalid merge patch")
+)
+
+// ApplyMergePatch applies a JSON Merge Patch (RFC 7396) to the value pointed
+// to by dst, which is typically a struct.
+//
+// Members of the patch object are matched to struct fields by their json tag
+// names, as encoding/json does. A member set to null clears the field: Option
+// fields become None, Nullable fields become null, and other fields are reset
+// to their zero value. A member holding an object is merged recursively into
+// nested structs, pointers to structs, maps with string keys and Some options;
+// any other member replaces the field. Fields that the patch does not mention
+// are left unchanged.
+//
+// Example:
+//
+//	type Profile struct {
+//		Name    string                `json:"name"`
+//		Website option.Option[string] `json:"website"`
+//		Address Address               `json:"address"`
+//	}
+//
+//	err := option.ApplyMergePatch(&profile, []byte(`{"website":null,"address":{"city":"Lodz"}}`))
+//	// profile.Website is None, profile.Address.City is "Lodz", everything else is unchanged
+func ApplyMergePatch[S any](dst *S, patch []byte) error {
+  if dst == nil {
+    return fmt.Errorf("%w: nil destination", ErrInvalidPatch)
+  }
+  if !json.Valid(patch) {
+    return fmt.Errorf("%w: malformed JSON", ErrInvalidPatch)
+  }
+  return mergePatch(reflect.ValueOf(dst).Elem(), bytes.TrimSpace(patch))
+}
+
+// DiffMergePatch returns a JSON Merge Patch (RFC 7396) that turns old into
+// new when applied with ApplyMergePatch. Both values are compared through
+// their JSON encoding, so None options and null Nullables are treated as null.
+// The patch for two equal values is the empty object {}.
+//
+// Example:
+//
+//	patch, err := option.DiffMergePatch(before, after)
+//	// {"address":{"city":"Lodz"},"website":null}
+func DiffMergePatch[S any](old, new S) ([]byte, error) {
+  oldDoc, err := toJSONValue(old)
+  if err != nil {
+    return nil, err
+  }
+  newDoc, err := toJSONValue(new)
+  if err != nil {
+    return nil, err
+  }
+
+  patch, changed := diffJSON(oldDoc, newDoc)
+  if !changed {
+    patch = map[string]any{}
+  }
+  return json.Marshal(patch)
+}
+
+// mergePatch merges patch into the settable value v.
+func mergePatch(v reflect.Value, patch json.RawMessage) error {
+  if string(patch) == "null" {
+    clearValue(v)
+    return nil
+  }
+  if patch[0] != '{' {
+    return replaceValue(v, patch)
+  }
+
+  if s, ok := v.Addr().Interface().(OptionalSetter); ok {
+    elem := reflect.New(s.ElemType()).Elem()
+    if current, ok := s.Interface(); ok {
+      elem.Set(reflect.ValueOf(current))
+    }
+    if err := mergePatch(elem, patch); err != nil {
+      return err
+    }
+    return s.SetSome(elem.Interface())
+  }
+  if n, ok := v.Addr().Interface().(nullableSetter); ok {
+    elem := reflect.New(n.elemType()).Elem()
+    if current, ok := n.valueAny(); ok {
+      elem.Set(reflect.ValueOf(current))
+    }
+    if err := mergePatch(elem, patch); err != nil {
+      return err
+    }
+    n.setValueAny(elem.Interface())
+    return nil
+  }
+
+  switch v.Kind() {
+  case reflect.Pointer:
+    if v.IsNil() {
+      v.Set(reflect.New(v.Type().Elem()))
+    }
+    return mergePatch(v.Elem(), patch)
+  case reflect.Interface:
+    if v.NumMethod() != 0 {
+      break
+    }
+    var target, p any
+    if !v.IsNil() {
+      target = v.Elem().Interface()
+    }
+    if err := json.Unmarshal(patch, &p); err != nil {
+      return err
+    }
+    if merged := mergeJSON(target, p); merged != nil {
+      v.Set(reflect.ValueOf(merged))
+    } else {
+      v.SetZero()
+    }
+    return nil
+  case reflect.Struct:
+    return mergeStruct(v, patch)
+  case reflect.Map:
+    if v.Type().Key().Kind() == reflect.String {
+      return mergeMap(v, patch)
+    }
+  }
+  return replaceValue(v, patch)
+}
+
+func mergeStruct(v reflect.Value, patch json.RawMessage) error {
+  var members map[string]json.RawMessage
+  if err := json.Unmarshal(patch, &members); err != nil {
+    return err
+  }
+  fields := jsonFieldsOf(v.Type())
+  for name, value := range members {
+    index, ok := fields.lookup(name)
+    if !ok {
+      continue
+    }
+    if err := mergePatch(v.FieldByIndex(index), value); err != nil {
+      return fmt.Errorf("%s: %w", name, err)
+    }
+  }
+  return nil
+}
+
+func mergeMap(v reflect.Value, patch json.RawMessage) error {
+  var members map[string]json.RawMessage
+  if err := json.Unmarshal(patch, &members); err != nil {
+    return err
+  }
+  if v.IsNil() {
+    v.Set(reflect.MakeMap(v.Type()))
+  }
+  for name, value := range members {
+    key := reflect.ValueOf(name).Convert(v.Type().Key())
+    if string(value) == "null" {
+      v.SetMapIndex(key, reflect.Value{})
+      continue
+    }
+    elem := reflect.New(v.Type().Elem()).Elem()
+    if current := v.MapIndex(key); current.IsValid() {
+      elem.Set(current)
+    }
+    if err := mergePatch(elem, value); err != nil {
+      return fmt.Errorf("%s: %w", name, err)
+    }
+    v.SetMapIndex(key, elem)
+  }
+  return nil
+}
+
+// clearValue applies a null patch member to v.
+func clearValue(v reflect.Value) {
+  switch s := v.Addr().Interface().(type) {
+  case OptionalSetter:
+    s.SetNone(nil)
+  case nullableSetter:
+    s.setNull()
+  default:
+    v.SetZero()
+  }
+}
+
+// replaceValue overwrites v with the JSON value in data.
+func replaceValue(v reflect.Value, data json.RawMessage) error {
+  value := reflect.New(v.Type())
+  if err := json.Unmarshal(data, value.Interface()); err != nil {
+    return err
+  }
+  v.Set(value.Elem())
+  return nil
+}
+
+// mergeJSON implements the MergePatch function of RFC 7396 on decoded JSON.
+func mergeJSON(target, patch any) any {
+  p, ok := patch.(map[string]any)
+  if !ok {
+    return patch
+  }
+  t, ok := target.(map[string]any)
+  if !ok {
+    t = map[string]any{}
+  }
+  for name, value := range p {
+    if value == nil {
+      delete(t, name)
+    } else {
+      t[name] = mergeJSON(t[name], value)
+    }
+  }
+  return t
+}
+
+// diffJSON returns the merge patch that turns a into b, and whether a and b
+// differ at all.
+func diffJSON(a, b any) (any, bool) {
+  ao, aok := a.(map[string]any)
+  bo, bok := b.(map[string]any)
+  if !aok || !bok {
+    return b, !reflect.DeepEqual(a, b)
+  }
+
+  patch := map[string]any{}
+  for name := range ao {
+    if _, ok := bo[name]; !ok {
+      patch[name] = nil
+    }
+  }
+  for name, value := range bo {
+    old, ok := ao[name]
+    if !ok {
+      patch[name] = value
+      continue
+    }
+    if sub, changed := diffJSON(old, value); changed {
+      patch[name] = sub
+    }
+  }
+  return patch, len(patch) > 0
+}
+
+// toJSONValue converts v to its decoded JSON representation.
+func toJSONValue(v any) (any, error) {
+  data, err := json.Marshal(v)
+  if err != nil {
+    return nil, err
+  }
+  var doc any
+  if err := json.Unmarshal(data, &doc); err != nil {
+    return nil, err
+  }
+  return doc, nil
+}
+
+// jsonFields maps the JSON member names of a struct type to field indexes.
+type jsonFields map[string][]int
+
+// lookup finds the field for a JSON member name, preferring an exact match
+// and falling back to a case-insensitive one like encoding/json.
+func (f jsonFields) lookup(name string) ([]int, bool) {
+  if index, ok := f[name]; ok {
+    return index, true
+  }
+  for n, index := range f {
+    if strings.EqualFold(n, name) {
+      return index, true
+    }
+  }
+  return nil, false
+}
+
+// jsonFieldsCache maps struct types to their jsonFields.
+var jsonFieldsCache sync.Map
+
+// jsonFieldsOf returns the JSON member names of the struct type t, including
+// the fields promoted from embedded structs without a json tag.
+func jsonFieldsOf(t reflect.Type) jsonFields {
+  if cached, ok := jsonFieldsCache.Load(t); ok {
+    return cached.(jsonFields)
+  }
+
+  fields := jsonFields{}
+  var walk func(t reflect.Type, prefix []int)
+  walk = func(t reflect.Type, prefix []int) {
+    for i := 0; i < t.NumField(); i++ {
+      sf := t.Field(i)
+      tag := sf.Tag.Get("json")
+      if tag == "-" {
+        continue
+      }
+      name, _, _ := strings.Cut(tag, ",")
+      index := append(append([]int(nil), prefix...), i)
+      if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+        walk(sf.Type, index)
+        continue
+      }
+      if !sf.IsExported() {
+        continue
+      }
+      if name == "" {
+        name = sf.Name
+      }
+      if _, ok := fields[name]; !ok || len(prefix) == 0 {
+        fields[name] = index
+      }
+    }
+  }
+  walk(t, nil)
+
+  cached, _ := jsonFieldsCache.LoadOrStore(t, fields)
+  return cached.(jsonFields)
+}
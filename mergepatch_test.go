@@ -0,0 +1,173 @@
+package option
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc7396Examples is the example table from RFC 7396, Appendix A.
+var rfc7396Examples = []struct {
+	original, patch, result string
+}{
+	{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+	{`{"a":"b"}`, `{"a":null}`, `{}`},
+	{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+	{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+	{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+	{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+	{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+	{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+	{`{"a":"b"}`, `["c"]`, `["c"]`},
+	{`{"a":"foo"}`, `null`, `null`},
+	{`{"a":"foo"}`, `"bar"`, `"bar"`},
+	{`{"e":null}`, `{"a":1}`, `{"e":null,"a":1}`},
+	{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+	{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+}
+
+func decodeJSON(t *testing.T, s string) any {
+	t.Helper()
+	var v any
+	require.NoError(t, json.Unmarshal([]byte(s), &v))
+	return v
+}
+
+func TestApplyMergePatch_RFC7396(t *testing.T) {
+	for _, tt := range rfc7396Examples {
+		t.Run(tt.original+" "+tt.patch, func(t *testing.T) {
+			doc := decodeJSON(t, tt.original)
+			require.NoError(t, ApplyMergePatch(&doc, []byte(tt.patch)))
+			assert.Equal(t, decodeJSON(t, tt.result), doc)
+		})
+	}
+}
+
+func TestDiffMergePatch_RFC7396(t *testing.T) {
+	for _, tt := range rfc7396Examples {
+		t.Run(tt.original+" "+tt.result, func(t *testing.T) {
+			patch, err := DiffMergePatch(decodeJSON(t, tt.original), decodeJSON(t, tt.result))
+			require.NoError(t, err)
+
+			doc := decodeJSON(t, tt.original)
+			require.NoError(t, ApplyMergePatch(&doc, patch))
+			assert.Equal(t, decodeJSON(t, tt.result), doc, "patch %s", patch)
+		})
+	}
+}
+
+type patchAddress struct {
+	Street string         `json:"street"`
+	City   Option[string] `json:"city"`
+}
+
+type patchMeta struct {
+	Version int `json:"version"`
+}
+
+type patchProfile struct {
+	patchMeta
+	Name     string                    `json:"name"`
+	Website  Option[string]            `json:"website"`
+	Nickname Nullable[string]          `json:"nickname"`
+	Address  patchAddress              `json:"address"`
+	Billing  Option[patchAddress]      `json:"billing"`
+	Previous *patchAddress             `json:"previous"`
+	Labels   map[string]string         `json:"labels"`
+	Scores   map[string]Option[int]    `json:"scores"`
+	Tags     []string                  `json:"tags"`
+	Extra    Nullable[map[string]bool] `json:"extra"`
+	Ignored  string                    `json:"-"`
+}
+
+func newPatchProfile() patchProfile {
+	return patchProfile{
+		patchMeta: patchMeta{Version: 1},
+		Name:      "gopher",
+		Website:   Some("https://go.dev"),
+		Nickname:  Value("gophy"),
+		Address:   patchAddress{Street: "Main", City: Some("Warsaw")},
+		Labels:    map[string]string{"team": "go", "tier": "gold"},
+		Tags:      []string{"a", "b"},
+		Ignored:   "keep",
+	}
+}
+
+func TestApplyMergePatch_Struct(t *testing.T) {
+	p := newPatchProfile()
+	patch := `{
+		"version": 2,
+		"website": null,
+		"nickname": null,
+		"address": {"city": "Lodz"},
+		"billing": {"street": "Side"},
+		"previous": {"city": "Krakow"},
+		"labels": {"tier": null, "region": "eu"},
+		"scores": {"x": 1},
+		"tags": ["c"],
+		"Ignored": "changed",
+		"unknown": true
+	}`
+	require.NoError(t, ApplyMergePatch(&p, []byte(patch)))
+
+	assert.Equal(t, 2, p.Version)
+	assert.Equal(t, "gopher", p.Name)
+	assert.True(t, p.Website.IsNone())
+	assert.True(t, p.Nickname.IsNull())
+	assert.Equal(t, "Main", p.Address.Street)
+	assert.Equal(t, "Lodz", p.Address.City.Unwrap())
+	assert.Equal(t, "Side", p.Billing.Unwrap().Street)
+	assert.True(t, p.Billing.Unwrap().City.IsNone())
+	assert.Equal(t, "Krakow", p.Previous.City.Unwrap())
+	assert.Equal(t, map[string]string{"team": "go", "region": "eu"}, p.Labels)
+	assert.Equal(t, 1, p.Scores["x"].Unwrap())
+	assert.Equal(t, []string{"c"}, p.Tags)
+	assert.Equal(t, "keep", p.Ignored)
+}
+
+func TestApplyMergePatch_MergesIntoSome(t *testing.T) {
+	p := patchProfile{
+		Billing: Some(patchAddress{Street: "Side", City: Some("Gdansk")}),
+		Extra:   Value(map[string]bool{"a": true}),
+	}
+	require.NoError(t, ApplyMergePatch(&p, []byte(`{"billing":{"city":null},"extra":{"b":true}}`)))
+
+	assert.Equal(t, "Side", p.Billing.Unwrap().Street)
+	assert.True(t, p.Billing.Unwrap().City.IsNone())
+	assert.Equal(t, map[string]bool{"a": true, "b": true}, p.Extra.Option().Unwrap())
+}
+
+func TestApplyMergePatch_Errors(t *testing.T) {
+	p := newPatchProfile()
+	assert.ErrorIs(t, ApplyMergePatch(&p, []byte(`{"name":`)), ErrInvalidPatch)
+	assert.ErrorIs(t, ApplyMergePatch[patchProfile](nil, []byte(`{}`)), ErrInvalidPatch)
+
+	err := ApplyMergePatch(&p, []byte(`{"address":{"street":1}}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "address")
+}
+
+func TestDiffMergePatch_Struct(t *testing.T) {
+	before := newPatchProfile()
+	after := newPatchProfile()
+	after.Website = None[string](nil)
+	after.Address.City = Some("Lodz")
+	after.Labels = map[string]string{"team": "go"}
+	after.Scores = map[string]Option[int]{"x": Some(1)}
+
+	patch, err := DiffMergePatch(before, after)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"website":null,"address":{"city":"Lodz"},"labels":{"tier":null},"scores":{"x":1}}`, string(patch))
+
+	require.NoError(t, ApplyMergePatch(&before, patch))
+	assert.Equal(t, after, before)
+}
+
+func TestDiffMergePatch_Equal(t *testing.T) {
+	patch, err := DiffMergePatch(newPatchProfile(), newPatchProfile())
+	require.NoError(t, err)
+	assert.Equal(t, `{}`, string(patch))
+}
@@ -0,0 +1,185 @@
+package option
+
+import (
+  "database/sql"
+  "database/sql/driver"
+  "encoding/json"
+  "errors"
+  "reflect"
+)
+
+var (
+  ErrAbsent = errors.New("option: value is absent")
+  ErrNull   = errors.New("option: value is null")
+)
+
+// nullableState is the state of a Nullable. The zero state is absent.
+type nullableState uint8
+
+const (
+  nullableAbsent nullableState = iota
+  nullableNull
+  nullableValue
+)
+
+// Nullable is a tri-state value that tells apart a field that was not sent,
+// a field that was explicitly set to null and a field that holds a value.
+// This is what PATCH-style partial updates need, where an Option would
+// collapse the first two states.
+//
+// The zero value of Nullable is absent, so fields missing from a JSON object
+// stay absent, and the omitzero tag option leaves absent fields out on encode.
+//
+// Example:
+//
+//	type UserPatch struct {
+//		Name     option.Nullable[string] `json:"name,omitzero"`
+//		Nickname option.Nullable[string] `json:"nickname,omitzero"`
+//	}
+//
+//	var patch UserPatch
+//	_ = json.Unmarshal([]byte(`{"nickname":null}`), &patch)
+//	// patch.Name is absent: leave it unchanged
+//	// patch.Nickname is null: clear it
+type Nullable[T any] struct {
+  state nullableState
+  value T
+}
+
+// Value returns a Nullable holding v.
+func Value[T any](v T) Nullable[T] {
+  return Nullable[T]{state: nullableValue, value: v}
+}
+
+// Null returns a Nullable that is explicitly null.
+func Null[T any]() Nullable[T] {
+  return Nullable[T]{state: nullableNull}
+}
+
+// Absent returns an absent Nullable. It is the same as the zero value.
+func Absent[T any]() Nullable[T] {
+  return Nullable[T]{}
+}
+
+// IsAbsent reports whether n was never set.
+func (n Nullable[T]) IsAbsent() bool {
+  return n.state == nullableAbsent
+}
+
+// IsNull reports whether n was explicitly set to null.
+func (n Nullable[T]) IsNull() bool {
+  return n.state == nullableNull
+}
+
+// IsValue reports whether n holds a value.
+func (n Nullable[T]) IsValue() bool {
+  return n.state == nullableValue
+}
+
+// IsZero reports whether n is absent, so encoding/json omits absent fields
+// tagged omitzero.
+func (n Nullable[T]) IsZero() bool {
+  return n.IsAbsent()
+}
+
+// Option converts n to an Option. A value becomes Some; an absent Nullable
+// becomes None with ErrAbsent and a null one None with ErrNull, so the two
+// can still be told apart with errors.Is.
+//
+// Example:
+//
+//	name := patch.Name.Option()
+//	if errors.Is(name.Error(), option.ErrNull) {
+//		// clear the name
+//	}
+func (n Nullable[T]) Option() Option[T] {
+  switch n.state {
+  case nullableValue:
+    return Some(n.value)
+  case nullableNull:
+    return None[T](ErrNull)
+  default:
+    return None[T](ErrAbsent)
+  }
+}
+
+// MarshalJSON implements json.Marshaler. A value is encoded as itself and a
+// null Nullable as null. An absent Nullable is encoded as null too, unless the
+// field is tagged omitzero, in which case it is left out.
+func (n Nullable[T]) MarshalJSON() ([]byte, error) {
+  if n.state != nullableValue {
+    return []byte("null"), nil
+  }
+  return json.Marshal(n.value)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. JSON null makes n null and any
+// other value is decoded into it. Keys missing from the object leave the
+// field absent. On error n is left unchanged.
+func (n *Nullable[T]) UnmarshalJSON(data []byte) error {
+  if string(data) == "null" {
+    *n = Null[T]()
+    return nil
+  }
+  var value T
+  if err := json.Unmarshal(data, &value); err != nil {
+    return err
+  }
+  *n = Value(value)
+  return nil
+}
+
+// Scan implements sql.Scanner. SQL NULL makes n null and any other value is
+// converted to T with the same rules as database/sql uses for Scan targets.
+func (n *Nullable[T]) Scan(src any) error {
+  var v sql.Null[T]
+  if err := v.Scan(src); err != nil {
+    return err
+  }
+  if !v.Valid {
+    *n = Null[T]()
+    return nil
+  }
+  *n = Value(v.V)
+  return nil
+}
+
+// Value implements driver.Valuer. Absent and null Nullables are stored as
+// SQL NULL; a value is converted with driver.DefaultParameterConverter, which
+// honours driver.Valuer implementations of T. Use IsAbsent to leave absent
+// fields out of an UPDATE rather than writing NULL.
+func (n Nullable[T]) Value() (driver.Value, error) {
+  if n.state != nullableValue {
+    return nil, nil
+  }
+  return driver.DefaultParameterConverter.ConvertValue(n.value)
+}
+
+// nullableSetter is the type-erased view of a *Nullable[T] used by the
+// reflection-based helpers in this package.
+type nullableSetter interface {
+  valueAny() (any, bool)
+  setValueAny(v any)
+  setNull()
+  elemType() reflect.Type
+}
+
+func (n *Nullable[T]) valueAny() (any, bool) {
+  if n.state != nullableValue {
+    return nil, false
+  }
+  return n.value, true
+}
+
+func (n *Nullable[T]) setValueAny(v any) {
+  value, _ := v.(T)
+  *n = Value(value)
+}
+
+func (n *Nullable[T]) setNull() {
+  *n = Null[T]()
+}
+
+func (n *Nullable[T]) elemType() reflect.Type {
+  return reflect.TypeFor[T]()
+}
@@ -0,0 +1,104 @@
+package option
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type userPatch struct {
+	Name     Nullable[string] `json:"name,omitzero"`
+	Nickname Nullable[string] `json:"nickname,omitzero"`
+	Age      Nullable[int]    `json:"age"`
+}
+
+func TestNullable_States(t *testing.T) {
+	var zero Nullable[int]
+	assert.True(t, zero.IsAbsent())
+	assert.True(t, zero.IsZero())
+	assert.True(t, Absent[int]().IsAbsent())
+
+	null := Null[int]()
+	assert.True(t, null.IsNull())
+	assert.False(t, null.IsAbsent())
+	assert.False(t, null.IsValue())
+
+	value := Value(0)
+	assert.True(t, value.IsValue())
+	assert.False(t, value.IsZero())
+}
+
+func TestNullable_Option(t *testing.T) {
+	assert.Equal(t, 42, Value(42).Option().Unwrap())
+	assert.ErrorIs(t, Null[int]().Option().Error(), ErrNull)
+	assert.ErrorIs(t, Absent[int]().Option().Error(), ErrAbsent)
+}
+
+func TestNullable_UnmarshalJSON(t *testing.T) {
+	var patch userPatch
+	require.NoError(t, json.Unmarshal([]byte(`{"nickname":null,"age":30}`), &patch))
+	assert.True(t, patch.Name.IsAbsent())
+	assert.True(t, patch.Nickname.IsNull())
+	assert.Equal(t, 30, patch.Age.Option().Unwrap())
+
+	err := json.Unmarshal([]byte(`{"age":"old"}`), &patch)
+	assert.Error(t, err)
+	assert.Equal(t, 30, patch.Age.Option().Unwrap())
+}
+
+func TestNullable_MarshalJSON(t *testing.T) {
+	out, err := json.Marshal(userPatch{Nickname: Null[string]()})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"nickname":null,"age":null}`, string(out))
+
+	out, err = json.Marshal(userPatch{Name: Value("gopher"), Age: Value(1)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"gopher","age":1}`, string(out))
+}
+
+func TestNullable_Scan(t *testing.T) {
+	var n Nullable[int64]
+	require.NoError(t, n.Scan(nil))
+	assert.True(t, n.IsNull())
+
+	require.NoError(t, n.Scan(int64(7)))
+	assert.Equal(t, int64(7), n.Option().Unwrap())
+
+	var s Nullable[string]
+	require.NoError(t, s.Scan([]byte("bytes")))
+	assert.Equal(t, "bytes", s.Option().Unwrap())
+
+	var i Nullable[int]
+	require.NoError(t, i.Scan("12"))
+	assert.Equal(t, 12, i.Option().Unwrap())
+	assert.Error(t, i.Scan("twelve"))
+
+	var ts Nullable[time.Time]
+	now := time.Now()
+	require.NoError(t, ts.Scan(now))
+	assert.True(t, now.Equal(ts.Option().Unwrap()))
+}
+
+func TestNullable_Value(t *testing.T) {
+	v, err := Absent[int]().Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = Null[int]().Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = Value(7).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), v)
+
+	v, err = Value(level(2)).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), v)
+
+	var _ driver.Valuer = Nullable[int]{}
+}
@@ -12,17 +12,22 @@ var (
 )
 
 // Option represents a value that may or may not be present.
+//
+// The zero value of Option is None with a nil error, so an Option field left
+// unset, or one that a decoder skips, reads as absent. Earlier versions
+// treated the zero value as Some of the zero T; use Some explicitly where
+// that is meant.
 type Option[T any] struct {
-  none bool
+  ok   bool
   some T
   err  error
 }
 
 func Some[T any](value T) Option[T] {
   if isNil(value) {
-    return Option[T]{none: true, err: ErrNilValue}
+    return Option[T]{err: ErrNilValue}
   }
-  return Option[T]{some: value}
+  return Option[T]{ok: true, some: value}
 }
 
 // None creates a new Option in the None state with the provided error.
@@ -48,7 +53,7 @@ func Some[T any](value T) Option[T] {
 // The type parameter T specifies what type the Option would contain if it were Some.
 // When using None, you must explicitly specify the type parameter since it cannot be inferred.
 func None[T any](err error) Option[T] {
-  return Option[T]{none: true, err: err}
+  return Option[T]{err: err}
 }
 
 // IsSome returns true if the Option is in the Some state (contains a value),
@@ -61,7 +66,7 @@ func None[T any](err error) Option[T] {
 //		// Handle the case where a value is present
 //	}
 func (o Option[T]) IsSome() bool {
-  return !o.none
+  return o.ok
 }
 
 // IsNone returns true if the Option is in the None state (contains no value),
@@ -76,7 +81,21 @@ func (o Option[T]) IsSome() bool {
 //		// Process the error...
 //	}
 func (o Option[T]) IsNone() bool {
-  return o.none
+  return !o.ok
+}
+
+// IsZero reports whether the Option is None. It lets encoders that honour
+// IsZero methods, such as encoding/json with omitzero and gopkg.in/yaml.v3
+// with omitempty on optionyaml.Option, leave None options out of their
+// output.
+//
+// Example:
+//
+//	type Config struct {
+//		Port option.Option[int] `json:"port,omitzero"`
+//	}
+func (o Option[T]) IsZero() bool {
+  return !o.ok
 }
 
 // Error returns the error associated with a None option, or nil if the option is Some.
@@ -91,7 +110,7 @@ func (o Option[T]) IsNone() bool {
 //
 // For Some options, this method always returns nil.
 func (o Option[T]) Error() error {
-  if o.none {
+  if !o.ok {
     return o.err
   }
 
@@ -112,7 +131,7 @@ func (o Option[T]) Error() error {
 //
 // It's recommended to check IsSome() before calling Unwrap to avoid panics.
 func (o Option[T]) Unwrap() T {
-  if o.none {
+  if !o.ok {
     panic("`Unwrap` called on `None` value")
   }
   return o.some
@@ -131,7 +150,7 @@ func (o Option[T]) Unwrap() T {
 //
 // This method provides a safe way to extract a value without risking a panic.
 func (o Option[T]) UnwrapOr(def T) T {
-  if o.none {
+  if !o.ok {
     return def
   }
   return o.some
@@ -150,7 +169,7 @@ func (o Option[T]) UnwrapOr(def T) T {
 //
 // This is useful when the default value is expensive to compute or needs to be determined dynamically.
 func (o Option[T]) UnwrapOrElse(f func() T) T {
-  if o.none {
+  if !o.ok {
     return f()
   }
   return o.some
@@ -174,9 +193,9 @@ func (o Option[T]) UnwrapOrElse(f func() T) T {
 //
 // This method is useful for conditionally processing values based on their properties.
 func (o Option[T]) Filter(predicate func(T) bool) Option[T] {
-  if o.none || !predicate(o.some) {
+  if !o.ok || !predicate(o.some) {
     var err error
-    if o.none {
+    if !o.ok {
       err = o.err
     } else {
       err = errors.New("option: value did not satisfy predicate")
@@ -202,7 +221,7 @@ func (o Option[T]) Filter(predicate func(T) bool) Option[T] {
 // This function is useful for transforming values without having to manually check if they exist.
 // The type parameters T and U represent the input and output types of the transformation.
 func Map[T, U any](o Option[T], f func(T) U) Option[U] {
-  if o.none {
+  if !o.ok {
     return None[U](o.err)
   }
   return Some(f(o.some))
@@ -243,7 +262,7 @@ func Map[T, U any](o Option[T], f func(T) U) Option[U] {
 // This function is useful for chaining operations that might fail, similar to monadic bind operations.
 // The type parameters T and U represent the input and output types of the transformation.
 func FlatMap[T, U any](o Option[T], f func(T) Option[U]) Option[U] {
-  if o.none {
+  if !o.ok {
     return None[U](o.err)
   }
   return f(o.some)
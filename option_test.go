@@ -34,6 +34,19 @@ func TestOption_IsSome_ErrorIsNil(t *testing.T) {
 	assert.Nil(t, some.Error())
 }
 
+func TestOption_ZeroValue(t *testing.T) {
+	var zero Option[int]
+	assert.True(t, zero.IsNone())
+	assert.Nil(t, zero.Error())
+	assert.Equal(t, 21, zero.UnwrapOr(21))
+}
+
+func TestOption_IsZero(t *testing.T) {
+	assert.True(t, Option[int]{}.IsZero())
+	assert.True(t, None[int](errors.New("some error")).IsZero())
+	assert.False(t, Some(0).IsZero())
+}
+
 func TestOption_Unwrap(t *testing.T) {
 	some := Some[int](42)
 	assert.Equal(t, 42, some.Unwrap())
@@ -0,0 +1,101 @@
+// Package optioncbor provides a minimal, dependency-free CBOR (RFC 8949)
+// encoder and decoder that understands option.Option values.
+//
+// CBOR distinguishes the simple values null and undefined, and this package
+// uses both to keep the two flavours of None apart: a None option without an
+// error is encoded as undefined, and a None option carrying an error is
+// encoded as null. Some options are encoded as their contained value.
+//
+// Supported Go types are booleans, integers, floats, strings, byte slices,
+// slices, arrays, maps, pointers, interfaces and structs. Struct fields are
+// encoded as a map keyed by field name, which can be overridden with a
+// `cbor:"name"` tag; `cbor:"-"` skips a field and the omitempty option leaves
+// out zero values, including None options.
+package optioncbor
+
+import (
+  "errors"
+  "fmt"
+  "reflect"
+
+  "github.com/kalpio/option"
+)
+
+var (
+  ErrNull          = errors.New("optioncbor: value was null")
+  ErrUnsupported   = errors.New("optioncbor: unsupported type")
+  ErrMalformed     = errors.New("optioncbor: malformed data")
+  ErrTypeMismatch  = errors.New("optioncbor: type mismatch")
+  ErrInvalidTarget = errors.New("optioncbor: decode target must be a non-nil pointer")
+)
+
+// CBOR major types.
+const (
+  majorUint   byte = 0
+  majorNegInt byte = 1
+  majorBytes  byte = 2
+  majorText   byte = 3
+  majorArray  byte = 4
+  majorMap    byte = 5
+  majorTag    byte = 6
+  majorSimple byte = 7
+)
+
+// CBOR simple values and the indefinite-length marker.
+const (
+  simpleFalse     byte = 0xf4
+  simpleTrue      byte = 0xf5
+  simpleNull      byte = 0xf6
+  simpleUndefined byte = 0xf7
+  breakCode       byte = 0xff
+)
+
+// maxDepth bounds the nesting of decoded items, so hostile input cannot
+// exhaust the stack.
+const maxDepth = 256
+
+var (
+  optionalType = reflect.TypeFor[option.Optional]()
+  setterType   = reflect.TypeFor[option.OptionalSetter]()
+  bytesType    = reflect.TypeFor[[]byte]()
+)
+
+// Marshal returns the CBOR encoding of v.
+//
+// Example:
+//
+//	type Reading struct {
+//		Sensor string                `cbor:"sensor"`
+//		Temp   option.Option[float64] `cbor:"temp"`
+//	}
+//
+//	data, err := optioncbor.Marshal(Reading{Sensor: "t1", Temp: option.None[float64](nil)})
+//	// {"sensor": "t1", "temp": undefined}
+func Marshal(v any) ([]byte, error) {
+  var e encoder
+  if err := e.encode(reflect.ValueOf(v)); err != nil {
+    return nil, err
+  }
+  return e.buf, nil
+}
+
+// Unmarshal decodes the CBOR data item in data into the value pointed to by v.
+//
+// Decoding into an option.Option turns undefined into None with a nil error
+// and null into None with ErrNull; any other item is decoded into the
+// contained value. For other types null and undefined set the zero value.
+// The data must hold exactly one CBOR data item.
+func Unmarshal(data []byte, v any) error {
+  rv := reflect.ValueOf(v)
+  if rv.Kind() != reflect.Pointer || rv.IsNil() {
+    return ErrInvalidTarget
+  }
+  d := decoder{data: data}
+  if err := d.decode(rv.Elem()); err != nil {
+    return err
+  }
+  if d.off != len(d.data) {
+    return fmt.Errorf("%w: %d trailing bytes", ErrMalformed, len(d.data)-d.off)
+  }
+  return nil
+}
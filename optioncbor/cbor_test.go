@@ -0,0 +1,161 @@
+package optioncbor
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type device struct {
+	ID       uint64                               `cbor:"id"`
+	Name     option.Option[string]                `cbor:"name"`
+	Firmware option.Option[[]byte]                `cbor:"fw"`
+	Readings []option.Option[float64]             `cbor:"r"`
+	Labels   map[string]string                    `cbor:"labels"`
+	Location option.Option[location]              `cbor:"loc"`
+	Limits   map[string]option.Option[int64]      `cbor:"limits"`
+	Parent   *device                              `cbor:"parent"`
+	Flags    option.Option[[]option.Option[bool]] `cbor:"flags"`
+}
+
+type location struct {
+	Lat, Lon float64
+}
+
+func TestRoundTrip(t *testing.T) {
+	in := device{
+		ID:       7,
+		Name:     option.Some("thermo"),
+		Firmware: option.None[[]byte](errors.New("unknown")),
+		Readings: []option.Option[float64]{option.Some(21.5), option.None[float64](nil), option.Some(math.Inf(-1))},
+		Labels:   map[string]string{"room": "lab"},
+		Location: option.Some(location{Lat: 52.2, Lon: 21.0}),
+		Limits:   map[string]option.Option[int64]{"max": option.Some(int64(math.MinInt64)), "min": option.None[int64](nil)},
+		Parent:   &device{ID: 1},
+		Flags:    option.Some([]option.Option[bool]{option.Some(true), option.None[bool](errors.New("x"))}),
+	}
+
+	data, err := Marshal(in)
+	require.NoError(t, err)
+
+	var out device
+	require.NoError(t, Unmarshal(data, &out))
+
+	assert.Equal(t, in.ID, out.ID)
+	assert.Equal(t, "thermo", out.Name.Unwrap())
+	assert.ErrorIs(t, out.Firmware.Error(), ErrNull)
+	assert.Len(t, out.Readings, 3)
+	assert.Equal(t, 21.5, out.Readings[0].Unwrap())
+	assert.True(t, out.Readings[1].IsNone())
+	assert.NoError(t, out.Readings[1].Error())
+	assert.Equal(t, math.Inf(-1), out.Readings[2].Unwrap())
+	assert.Equal(t, in.Labels, out.Labels)
+	assert.Equal(t, in.Location.Unwrap(), out.Location.Unwrap())
+	assert.Equal(t, int64(math.MinInt64), out.Limits["max"].Unwrap())
+	assert.NoError(t, out.Limits["min"].Error())
+	assert.True(t, out.Limits["min"].IsNone())
+	assert.Equal(t, uint64(1), out.Parent.ID)
+	assert.True(t, out.Parent.Name.IsNone())
+	flags := out.Flags.Unwrap()
+	assert.True(t, flags[0].Unwrap())
+	assert.ErrorIs(t, flags[1].Error(), ErrNull)
+}
+
+func TestRoundTrip_OptionPointer(t *testing.T) {
+	type record struct {
+		P *option.Option[int] `cbor:"p"`
+		Q *option.Option[int] `cbor:"q,omitempty"`
+	}
+
+	data, err := Marshal(record{})
+	require.NoError(t, err)
+	var out record
+	require.NoError(t, Unmarshal(data, &out))
+	assert.Nil(t, out.P)
+	assert.Nil(t, out.Q)
+
+	p, q := option.Some(1), option.None[int](nil)
+	data, err = Marshal(record{P: &p, Q: &q})
+	require.NoError(t, err)
+	require.NoError(t, Unmarshal(data, &out))
+	require.NotNil(t, out.P)
+	assert.Equal(t, 1, out.P.Unwrap())
+	// None is encoded as undefined, which decodes into a nil pointer.
+	assert.Nil(t, out.Q)
+}
+
+func TestRoundTrip_Deterministic(t *testing.T) {
+	m := map[string]int{"b": 2, "a": 1, "c": 3, "aa": 4}
+	first, err := Marshal(m)
+	require.NoError(t, err)
+	for range 10 {
+		again, err := Marshal(m)
+		require.NoError(t, err)
+		assert.Equal(t, first, again)
+	}
+}
+
+func FuzzRoundTrip(f *testing.F) {
+	f.Add(true, int64(0), uint64(0), 0.0, "", []byte(nil), "")
+	f.Add(false, int64(-1), uint64(math.MaxUint64), math.Inf(1), "gopher", []byte{0, 1}, "offline")
+	f.Add(true, int64(math.MinInt64), uint64(24), math.NaN(), "\xff", []byte{}, "")
+
+	f.Fuzz(func(t *testing.T, some bool, i int64, u uint64, x float64, s string, b []byte, msg string) {
+		type record struct {
+			I option.Option[int64]
+			U option.Option[uint64]
+			X option.Option[float64]
+			S option.Option[string]
+			B []byte
+			M map[string]option.Option[string]
+			P *option.Option[int64]
+		}
+
+		in := record{B: b, M: map[string]option.Option[string]{s: option.Some(msg)}}
+		if some {
+			in.I, in.U, in.X, in.S = option.Some(i), option.Some(u), option.Some(x), option.Some(s)
+			in.P = &in.I
+		} else if msg != "" {
+			in.S = option.None[string](errors.New(msg))
+		}
+
+		data, err := Marshal(in)
+		require.NoError(t, err)
+
+		var out record
+		require.NoError(t, Unmarshal(data, &out))
+		assert.True(t, in.I.Equal(out.I))
+		assert.True(t, in.U.Equal(out.U))
+		assert.Equal(t, in.X.IsSome(), out.X.IsSome())
+		if some {
+			assert.Equal(t, math.Float64bits(x), math.Float64bits(out.X.Unwrap()))
+		}
+		assert.True(t, in.S.Equal(out.S))
+		assert.Equal(t, in.S.Error() != nil, out.S.Error() != nil)
+		assert.Equal(t, len(b), len(out.B))
+		assert.Equal(t, msg, out.M[s].Unwrap())
+		assert.Equal(t, in.P == nil, out.P == nil)
+		if some {
+			assert.Equal(t, i, out.P.Unwrap())
+		}
+	})
+}
+
+func FuzzUnmarshal(f *testing.F) {
+	for _, seed := range []string{"00", "f6", "f7", "a26161016162820203", "9f018202039f0405ffff", "5f42010243030405ff"} {
+		f.Add(mustHex(f, seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var v any
+		if err := Unmarshal(data, &v); err != nil {
+			return
+		}
+		var d device
+		_ = Unmarshal(data, &d)
+	})
+}
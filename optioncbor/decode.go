@@ -0,0 +1,519 @@
+package optioncbor
+
+import (
+  "encoding/binary"
+  "fmt"
+  "math"
+  "reflect"
+  "strings"
+
+  "github.com/kalpio/option"
+)
+
+// header is the decoded initial byte and argument of a data item.
+type header struct {
+  major      byte
+  info       byte
+  arg        uint64
+  indefinite bool
+}
+
+// decoder reads CBOR data items from data.
+type decoder struct {
+  data  []byte
+  off   int
+  depth int
+}
+
+// peek returns the initial byte of the next data item without consuming it.
+func (d *decoder) peek() (byte, error) {
+  if d.off >= len(d.data) {
+    return 0, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+  }
+  return d.data[d.off], nil
+}
+
+// readHead consumes the initial byte and argument of the next data item.
+func (d *decoder) readHead() (header, error) {
+  b, err := d.peek()
+  if err != nil {
+    return header{}, err
+  }
+  d.off++
+
+  h := header{major: b >> 5, info: b & 0x1f}
+  switch {
+  case h.info < 24:
+    h.arg = uint64(h.info)
+  case h.info <= 27:
+    size := 1 << (h.info - 24)
+    if len(d.data)-d.off < size {
+      return header{}, fmt.Errorf("%w: unexpected end of data", ErrMalformed)
+    }
+    p := d.data[d.off : d.off+size]
+    d.off += size
+    switch size {
+    case 1:
+      h.arg = uint64(p[0])
+    case 2:
+      h.arg = uint64(binary.BigEndian.Uint16(p))
+    case 4:
+      h.arg = uint64(binary.BigEndian.Uint32(p))
+    default:
+      h.arg = binary.BigEndian.Uint64(p)
+    }
+  case h.info == 31 && h.major >= majorBytes && h.major <= majorMap:
+    h.indefinite = true
+  default:
+    return header{}, fmt.Errorf("%w: invalid initial byte %#x", ErrMalformed, b)
+  }
+  return h, nil
+}
+
+// atBreak consumes the break code that ends an indefinite-length item and
+// reports whether it was found.
+func (d *decoder) atBreak() (bool, error) {
+  b, err := d.peek()
+  if err != nil {
+    return false, err
+  }
+  if b == breakCode {
+    d.off++
+    return true, nil
+  }
+  return false, nil
+}
+
+// readString reads the payload of a byte or text string with header h.
+func (d *decoder) readString(h header) ([]byte, error) {
+  if !h.indefinite {
+    if h.arg > uint64(len(d.data)-d.off) {
+      return nil, fmt.Errorf("%w: string length %d exceeds data", ErrMalformed, h.arg)
+    }
+    s := d.data[d.off : d.off+int(h.arg)]
+    d.off += int(h.arg)
+    return s, nil
+  }
+
+  var s []byte
+  for {
+    done, err := d.atBreak()
+    if err != nil {
+      return nil, err
+    }
+    if done {
+      return s, nil
+    }
+    chunk, err := d.readHead()
+    if err != nil {
+      return nil, err
+    }
+    if chunk.major != h.major || chunk.indefinite {
+      return nil, fmt.Errorf("%w: invalid chunk in indefinite-length string", ErrMalformed)
+    }
+    p, err := d.readString(chunk)
+    if err != nil {
+      return nil, err
+    }
+    s = append(s, p...)
+  }
+}
+
+// checkLength rejects item counts that cannot fit in the remaining data,
+// before anything is allocated for them.
+func (d *decoder) checkLength(n uint64) error {
+  if n > uint64(len(d.data)-d.off) {
+    return fmt.Errorf("%w: length %d exceeds data", ErrMalformed, n)
+  }
+  return nil
+}
+
+// forEach calls f once per element of the array or map with header h,
+// handling both definite and indefinite lengths.
+func (d *decoder) forEach(h header, f func() error) error {
+  if h.indefinite {
+    for {
+      done, err := d.atBreak()
+      if err != nil || done {
+        return err
+      }
+      if err := f(); err != nil {
+        return err
+      }
+    }
+  }
+  if err := d.checkLength(h.arg); err != nil {
+    return err
+  }
+  for i := uint64(0); i < h.arg; i++ {
+    if err := f(); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// skipTags consumes any semantic tags in front of the next data item. Tags
+// are not interpreted.
+func (d *decoder) skipTags() error {
+  for {
+    b, err := d.peek()
+    if err != nil {
+      return err
+    }
+    if b>>5 != majorTag {
+      return nil
+    }
+    if _, err := d.readHead(); err != nil {
+      return err
+    }
+  }
+}
+
+// decode decodes the next data item into the settable value v.
+func (d *decoder) decode(v reflect.Value) error {
+  d.depth++
+  defer func() { d.depth-- }()
+  if d.depth > maxDepth {
+    return fmt.Errorf("%w: nesting too deep", ErrMalformed)
+  }
+  if err := d.skipTags(); err != nil {
+    return err
+  }
+
+  b, err := d.peek()
+  if err != nil {
+    return err
+  }
+  if v.Addr().Type().Implements(setterType) {
+    return d.decodeOption(b, v.Addr().Interface().(option.OptionalSetter))
+  }
+  if b == simpleNull || b == simpleUndefined {
+    d.off++
+    v.SetZero()
+    return nil
+  }
+
+  switch v.Kind() {
+  case reflect.Pointer:
+    p := reflect.New(v.Type().Elem())
+    if err := d.decode(p.Elem()); err != nil {
+      return err
+    }
+    v.Set(p)
+    return nil
+  case reflect.Interface:
+    if v.NumMethod() != 0 {
+      return fmt.Errorf("%w: cannot decode into %s", ErrUnsupported, v.Type())
+    }
+    x, err := d.decodeAny()
+    if err != nil {
+      return err
+    }
+    if x != nil {
+      v.Set(reflect.ValueOf(x))
+    } else {
+      v.SetZero()
+    }
+    return nil
+  }
+
+  h, err := d.readHead()
+  if err != nil {
+    return err
+  }
+  switch h.major {
+  case majorUint, majorNegInt:
+    return setInt(v, h)
+  case majorBytes, majorText:
+    s, err := d.readString(h)
+    if err != nil {
+      return err
+    }
+    return setString(v, h.major, s)
+  case majorArray:
+    return d.decodeArray(v, h)
+  case majorMap:
+    return d.decodeMap(v, h)
+  case majorSimple:
+    return setSimple(v, h)
+  }
+  return fmt.Errorf("%w: unexpected major type %d", ErrMalformed, h.major)
+}
+
+// decodeOption decodes undefined as None with a nil error, null as None with
+// ErrNull and anything else as Some.
+func (d *decoder) decodeOption(b byte, o option.OptionalSetter) error {
+  switch b {
+  case simpleUndefined:
+    d.off++
+    o.SetNone(nil)
+    return nil
+  case simpleNull:
+    d.off++
+    o.SetNone(ErrNull)
+    return nil
+  }
+
+  value := reflect.New(o.ElemType()).Elem()
+  if err := d.decode(value); err != nil {
+    return err
+  }
+  return o.SetSome(value.Interface())
+}
+
+func (d *decoder) decodeArray(v reflect.Value, h header) error {
+  switch v.Kind() {
+  case reflect.Slice:
+    if !h.indefinite {
+      if err := d.checkLength(h.arg); err != nil {
+        return err
+      }
+      v.Set(reflect.MakeSlice(v.Type(), 0, int(h.arg)))
+    } else {
+      v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+    }
+    return d.forEach(h, func() error {
+      elem := reflect.New(v.Type().Elem()).Elem()
+      if err := d.decode(elem); err != nil {
+        return err
+      }
+      v.Set(reflect.Append(v, elem))
+      return nil
+    })
+  case reflect.Array:
+    i := 0
+    err := d.forEach(h, func() error {
+      if i >= v.Len() {
+        return fmt.Errorf("%w: array too long for %s", ErrTypeMismatch, v.Type())
+      }
+      i++
+      return d.decode(v.Index(i - 1))
+    })
+    if err != nil {
+      return err
+    }
+    for ; i < v.Len(); i++ {
+      v.Index(i).SetZero()
+    }
+    return nil
+  }
+  return fmt.Errorf("%w: cannot decode array into %s", ErrTypeMismatch, v.Type())
+}
+
+func (d *decoder) decodeMap(v reflect.Value, h header) error {
+  switch v.Kind() {
+  case reflect.Map:
+    if v.IsNil() {
+      v.Set(reflect.MakeMap(v.Type()))
+    }
+    return d.forEach(h, func() error {
+      key := reflect.New(v.Type().Key()).Elem()
+      if err := d.decode(key); err != nil {
+        return err
+      }
+      if !key.Comparable() {
+        return fmt.Errorf("%w: map key is not comparable", ErrTypeMismatch)
+      }
+      elem := reflect.New(v.Type().Elem()).Elem()
+      if err := d.decode(elem); err != nil {
+        return err
+      }
+      v.SetMapIndex(key, elem)
+      return nil
+    })
+  case reflect.Struct:
+    fields := fieldsOf(v.Type())
+    return d.forEach(h, func() error {
+      var name string
+      if err := d.decode(reflect.ValueOf(&name).Elem()); err != nil {
+        return err
+      }
+      for _, f := range fields {
+        if f.name == name {
+          return d.decode(v.Field(f.index))
+        }
+      }
+      for _, f := range fields {
+        if strings.EqualFold(f.name, name) {
+          return d.decode(v.Field(f.index))
+        }
+      }
+      return d.skip()
+    })
+  }
+  return fmt.Errorf("%w: cannot decode map into %s", ErrTypeMismatch, v.Type())
+}
+
+// skip consumes the next data item without storing it.
+func (d *decoder) skip() error {
+  _, err := d.decodeAny()
+  return err
+}
+
+// decodeAny decodes the next data item into its natural Go representation:
+// uint64, int64, float64, bool, string, []byte, []any, map[any]any or nil.
+func (d *decoder) decodeAny() (any, error) {
+  d.depth++
+  defer func() { d.depth-- }()
+  if d.depth > maxDepth {
+    return nil, fmt.Errorf("%w: nesting too deep", ErrMalformed)
+  }
+  if err := d.skipTags(); err != nil {
+    return nil, err
+  }
+
+  h, err := d.readHead()
+  if err != nil {
+    return nil, err
+  }
+  switch h.major {
+  case majorUint:
+    return h.arg, nil
+  case majorNegInt:
+    if h.arg > math.MaxInt64 {
+      return nil, fmt.Errorf("%w: negative integer overflows int64", ErrTypeMismatch)
+    }
+    return -1 - int64(h.arg), nil
+  case majorBytes:
+    s, err := d.readString(h)
+    return append([]byte(nil), s...), err
+  case majorText:
+    s, err := d.readString(h)
+    return string(s), err
+  case majorArray:
+    var a []any
+    err := d.forEach(h, func() error {
+      x, err := d.decodeAny()
+      a = append(a, x)
+      return err
+    })
+    return a, err
+  case majorMap:
+    m := map[any]any{}
+    err := d.forEach(h, func() error {
+      k, err := d.decodeAny()
+      if err != nil {
+        return err
+      }
+      if k != nil && !reflect.TypeOf(k).Comparable() {
+        return fmt.Errorf("%w: map key is not comparable", ErrTypeMismatch)
+      }
+      x, err := d.decodeAny()
+      m[k] = x
+      return err
+    })
+    return m, err
+  }
+
+  switch {
+  case h.info == simpleFalse&0x1f:
+    return false, nil
+  case h.info == simpleTrue&0x1f:
+    return true, nil
+  case h.info == simpleNull&0x1f, h.info == simpleUndefined&0x1f:
+    return nil, nil
+  case h.info >= 25 && h.info <= 27:
+    return floatOf(h), nil
+  }
+  return nil, fmt.Errorf("%w: unsupported simple value %d", ErrMalformed, h.arg)
+}
+
+// floatOf returns the value of a half, single or double precision float.
+func floatOf(h header) float64 {
+  switch h.info {
+  case 25:
+    return halfToFloat(uint16(h.arg))
+  case 26:
+    return float64(math.Float32frombits(uint32(h.arg)))
+  }
+  return math.Float64frombits(h.arg)
+}
+
+// halfToFloat converts an IEEE 754 half-precision float to a float64.
+func halfToFloat(h uint16) float64 {
+  exp := int(h>>10) & 0x1f
+  mant := float64(h & 0x3ff)
+  var f float64
+  switch exp {
+  case 0:
+    f = math.Ldexp(mant, -24)
+  case 0x1f:
+    if mant == 0 {
+      f = math.Inf(1)
+    } else {
+      f = math.NaN()
+    }
+  default:
+    f = math.Ldexp(mant+1024, exp-25)
+  }
+  if h&0x8000 != 0 {
+    return -f
+  }
+  return f
+}
+
+func setInt(v reflect.Value, h header) error {
+  switch v.Kind() {
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    if h.arg > math.MaxInt64 {
+      return fmt.Errorf("%w: integer overflows %s", ErrTypeMismatch, v.Type())
+    }
+    n := int64(h.arg)
+    if h.major == majorNegInt {
+      n = -1 - n
+    }
+    if v.OverflowInt(n) {
+      return fmt.Errorf("%w: integer overflows %s", ErrTypeMismatch, v.Type())
+    }
+    v.SetInt(n)
+    return nil
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    if h.major == majorNegInt || v.OverflowUint(h.arg) {
+      return fmt.Errorf("%w: integer overflows %s", ErrTypeMismatch, v.Type())
+    }
+    v.SetUint(h.arg)
+    return nil
+  case reflect.Float32, reflect.Float64:
+    f := float64(h.arg)
+    if h.major == majorNegInt {
+      f = -1 - f
+    }
+    v.SetFloat(f)
+    return nil
+  }
+  return fmt.Errorf("%w: cannot decode integer into %s", ErrTypeMismatch, v.Type())
+}
+
+func setString(v reflect.Value, major byte, s []byte) error {
+  switch {
+  case v.Kind() == reflect.String:
+    v.SetString(string(s))
+    return nil
+  case v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8:
+    v.SetBytes(append([]byte{}, s...))
+    return nil
+  }
+  if major == majorBytes {
+    return fmt.Errorf("%w: cannot decode byte string into %s", ErrTypeMismatch, v.Type())
+  }
+  return fmt.Errorf("%w: cannot decode text string into %s", ErrTypeMismatch, v.Type())
+}
+
+func setSimple(v reflect.Value, h header) error {
+  switch {
+  case h.info == simpleFalse&0x1f || h.info == simpleTrue&0x1f:
+    if v.Kind() != reflect.Bool {
+      return fmt.Errorf("%w: cannot decode boolean into %s", ErrTypeMismatch, v.Type())
+    }
+    v.SetBool(h.info == simpleTrue&0x1f)
+    return nil
+  case h.info >= 25 && h.info <= 27:
+    if v.Kind() != reflect.Float32 && v.Kind() != reflect.Float64 {
+      return fmt.Errorf("%w: cannot decode float into %s", ErrTypeMismatch, v.Type())
+    }
+    v.SetFloat(floatOf(h))
+    return nil
+  }
+  return fmt.Errorf("%w: unsupported simple value %d", ErrMalformed, h.arg)
+}
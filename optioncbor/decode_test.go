@@ -0,0 +1,131 @@
+package optioncbor
+
+import (
+	"encoding/hex"
+	"math"
+	"testing"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustHex(t testing.TB, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(s)
+	require.NoError(t, err)
+	return data
+}
+
+func TestUnmarshal_RFCExamples(t *testing.T) {
+	tests := []struct {
+		data string
+		want any
+	}{
+		{"00", uint64(0)},
+		{"1bffffffffffffffff", uint64(math.MaxUint64)},
+		{"3903e7", int64(-1000)},
+		{"f90000", 0.0},
+		{"f93c00", 1.0},
+		{"f97bff", 65504.0},
+		{"f9c400", -4.0},
+		{"f90001", 5.960464477539063e-8},
+		{"f97c00", math.Inf(1)},
+		{"fa47c35000", 100000.0},
+		{"fb3ff199999999999a", 1.1},
+		{"f4", false},
+		{"f6", nil},
+		{"f7", nil},
+		{"4401020304", []byte{1, 2, 3, 4}},
+		{"6449455446", "IETF"},
+		{"8301820203820405", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}},
+		{"a201020304", map[any]any{uint64(1): uint64(2), uint64(3): uint64(4)}},
+		{"c074323031332d30332d32315432303a30343a30305a", "2013-03-21T20:04:00Z"},
+		{"5f42010243030405ff", []byte{1, 2, 3, 4, 5}},
+		{"7f657374726561646d696e67ff", "streaming"},
+		{"9f018202039f0405ffff", []any{uint64(1), []any{uint64(2), uint64(3)}, []any{uint64(4), uint64(5)}}},
+		{"bf61610161629f0203ffff", map[any]any{"a": uint64(1), "b": []any{uint64(2), uint64(3)}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.data, func(t *testing.T) {
+			var got any
+			require.NoError(t, Unmarshal(mustHex(t, tt.data), &got))
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestUnmarshal_Option(t *testing.T) {
+	var o option.Option[int]
+	require.NoError(t, Unmarshal(mustHex(t, "182a"), &o))
+	assert.Equal(t, 42, o.Unwrap())
+
+	require.NoError(t, Unmarshal(mustHex(t, "f7"), &o))
+	assert.True(t, o.IsNone())
+	assert.NoError(t, o.Error())
+
+	require.NoError(t, Unmarshal(mustHex(t, "f6"), &o))
+	assert.True(t, o.IsNone())
+	assert.ErrorIs(t, o.Error(), ErrNull)
+}
+
+func TestUnmarshal_Struct(t *testing.T) {
+	type reading struct {
+		Sensor string                 `cbor:"s"`
+		Temp   option.Option[float64] `cbor:"t"`
+		Hum    option.Option[int]
+		Tags   map[string]option.Option[string]
+	}
+
+	// {"s": "x", "t": 21.5 as half float, "hum": null, "extra": [1], "Tags": {"a": undefined}}
+	data := mustHex(t, "a5617361786174f94d606368756df665657874726181016454616773a16161f7")
+	var r reading
+	require.NoError(t, Unmarshal(data, &r))
+	assert.Equal(t, "x", r.Sensor)
+	assert.Equal(t, 21.5, r.Temp.Unwrap())
+	assert.ErrorIs(t, r.Hum.Error(), ErrNull)
+	assert.True(t, r.Tags["a"].IsNone())
+	assert.NoError(t, r.Tags["a"].Error())
+}
+
+func TestUnmarshal_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		into any
+		err  error
+	}{
+		{"empty", "", new(int), ErrMalformed},
+		{"truncated argument", "19", new(int), ErrMalformed},
+		{"truncated string", "6449", new(string), ErrMalformed},
+		{"huge array", "9bffffffffffffffff", new([]int), ErrMalformed},
+		{"trailing bytes", "0000", new(int), ErrMalformed},
+		{"reserved info", "1c", new(int), ErrMalformed},
+		{"stray break", "ff", new(int), ErrMalformed},
+		{"overflow", "190100", new(int8), ErrTypeMismatch},
+		{"negative into uint", "20", new(uint), ErrTypeMismatch},
+		{"string into int", "6161", new(int), ErrTypeMismatch},
+		{"array into string", "80", new(string), ErrTypeMismatch},
+		{"array too long", "820102", new([1]int), ErrTypeMismatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, Unmarshal(mustHex(t, tt.data), tt.into), tt.err)
+		})
+	}
+
+	assert.ErrorIs(t, Unmarshal([]byte{0}, nil), ErrInvalidTarget)
+	var n int
+	assert.ErrorIs(t, Unmarshal([]byte{0}, n), ErrInvalidTarget)
+}
+
+func TestUnmarshal_DeepNesting(t *testing.T) {
+	data := make([]byte, 10000)
+	for i := range data {
+		data[i] = 0x81
+	}
+	var v any
+	assert.ErrorIs(t, Unmarshal(data, &v), ErrMalformed)
+}
@@ -0,0 +1,231 @@
+package optioncbor
+
+import (
+  "bytes"
+  "encoding/binary"
+  "fmt"
+  "math"
+  "reflect"
+  "slices"
+  "strings"
+  "sync"
+
+  "github.com/kalpio/option"
+)
+
+// field describes how a struct field is encoded.
+type field struct {
+  name      string
+  index     int
+  omitEmpty bool
+}
+
+// fieldCache maps struct types to their []field plans.
+var fieldCache sync.Map
+
+// fieldsOf returns the encoding plan for the struct type t.
+func fieldsOf(t reflect.Type) []field {
+  if cached, ok := fieldCache.Load(t); ok {
+    return cached.([]field)
+  }
+
+  var fields []field
+  for i := 0; i < t.NumField(); i++ {
+    sf := t.Field(i)
+    if !sf.IsExported() {
+      continue
+    }
+    tag := sf.Tag.Get("cbor")
+    if tag == "-" {
+      continue
+    }
+    name, opts, _ := strings.Cut(tag, ",")
+    if name == "" {
+      name = sf.Name
+    }
+    fields = append(fields, field{
+      name:      name,
+      index:     i,
+      omitEmpty: slices.Contains(strings.Split(opts, ","), "omitempty"),
+    })
+  }
+
+  cached, _ := fieldCache.LoadOrStore(t, fields)
+  return cached.([]field)
+}
+
+// encoder accumulates the CBOR encoding of a value.
+type encoder struct {
+  buf []byte
+}
+
+// writeHead writes the initial byte and argument of a data item.
+func (e *encoder) writeHead(major byte, n uint64) {
+  switch {
+  case n < 24:
+    e.buf = append(e.buf, major<<5|byte(n))
+  case n <= math.MaxUint8:
+    e.buf = append(e.buf, major<<5|24, byte(n))
+  case n <= math.MaxUint16:
+    e.buf = binary.BigEndian.AppendUint16(append(e.buf, major<<5|25), uint16(n))
+  case n <= math.MaxUint32:
+    e.buf = binary.BigEndian.AppendUint32(append(e.buf, major<<5|26), uint32(n))
+  default:
+    e.buf = binary.BigEndian.AppendUint64(append(e.buf, major<<5|27), n)
+  }
+}
+
+func (e *encoder) encode(v reflect.Value) error {
+  if !v.IsValid() {
+    e.buf = append(e.buf, simpleNull)
+    return nil
+  }
+  // Pointers to options also implement Optional, but are encoded through
+  // the pointer case so that nil pointers become null.
+  if v.Kind() != reflect.Pointer && v.Type().Implements(optionalType) {
+    return e.encodeOption(v.Interface().(option.Optional))
+  }
+
+  switch v.Kind() {
+  case reflect.Bool:
+    if v.Bool() {
+      e.buf = append(e.buf, simpleTrue)
+    } else {
+      e.buf = append(e.buf, simpleFalse)
+    }
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    if n := v.Int(); n < 0 {
+      e.writeHead(majorNegInt, uint64(-1-n))
+    } else {
+      e.writeHead(majorUint, uint64(n))
+    }
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    e.writeHead(majorUint, v.Uint())
+  case reflect.Float32:
+    e.buf = binary.BigEndian.AppendUint32(append(e.buf, majorSimple<<5|26), math.Float32bits(float32(v.Float())))
+  case reflect.Float64:
+    e.buf = binary.BigEndian.AppendUint64(append(e.buf, majorSimple<<5|27), math.Float64bits(v.Float()))
+  case reflect.String:
+    e.writeHead(majorText, uint64(v.Len()))
+    e.buf = append(e.buf, v.String()...)
+  case reflect.Slice:
+    if v.IsNil() {
+      e.buf = append(e.buf, simpleNull)
+      return nil
+    }
+    if v.Type().Elem().Kind() == reflect.Uint8 {
+      e.writeHead(majorBytes, uint64(v.Len()))
+      e.buf = append(e.buf, v.Bytes()...)
+      return nil
+    }
+    return e.encodeArray(v)
+  case reflect.Array:
+    return e.encodeArray(v)
+  case reflect.Map:
+    if v.IsNil() {
+      e.buf = append(e.buf, simpleNull)
+      return nil
+    }
+    return e.encodeMap(v)
+  case reflect.Pointer, reflect.Interface:
+    if v.IsNil() {
+      e.buf = append(e.buf, simpleNull)
+      return nil
+    }
+    return e.encode(v.Elem())
+  case reflect.Struct:
+    return e.encodeStruct(v)
+  default:
+    return fmt.Errorf("%w: %s", ErrUnsupported, v.Type())
+  }
+  return nil
+}
+
+// encodeOption encodes Some as its contained value, None without an error as
+// undefined and None with an error as null.
+func (e *encoder) encodeOption(o option.Optional) error {
+  if v, ok := o.Interface(); ok {
+    return e.encode(reflect.ValueOf(v))
+  }
+  if o.Error() == nil {
+    e.buf = append(e.buf, simpleUndefined)
+  } else {
+    e.buf = append(e.buf, simpleNull)
+  }
+  return nil
+}
+
+func (e *encoder) encodeArray(v reflect.Value) error {
+  e.writeHead(majorArray, uint64(v.Len()))
+  for i := 0; i < v.Len(); i++ {
+    if err := e.encode(v.Index(i)); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// encodeMap encodes the entries of v sorted by their encoded keys, so equal
+// maps always produce the same bytes.
+func (e *encoder) encodeMap(v reflect.Value) error {
+  type entry struct {
+    key   []byte
+    value reflect.Value
+  }
+
+  entries := make([]entry, 0, v.Len())
+  iter := v.MapRange()
+  for iter.Next() {
+    var ke encoder
+    if err := ke.encode(iter.Key()); err != nil {
+      return err
+    }
+    entries = append(entries, entry{key: ke.buf, value: iter.Value()})
+  }
+  slices.SortFunc(entries, func(a, b entry) int { return bytes.Compare(a.key, b.key) })
+
+  e.writeHead(majorMap, uint64(len(entries)))
+  for _, en := range entries {
+    e.buf = append(e.buf, en.key...)
+    if err := e.encode(en.value); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+func (e *encoder) encodeStruct(v reflect.Value) error {
+  fields := fieldsOf(v.Type())
+  present := make([]field, 0, len(fields))
+  for _, f := range fields {
+    if f.omitEmpty && isEmpty(v.Field(f.index)) {
+      continue
+    }
+    present = append(present, f)
+  }
+
+  e.writeHead(majorMap, uint64(len(present)))
+  for _, f := range present {
+    e.writeHead(majorText, uint64(len(f.name)))
+    e.buf = append(e.buf, f.name...)
+    if err := e.encode(v.Field(f.index)); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// isEmpty reports whether a field tagged omitempty should be left out.
+func isEmpty(v reflect.Value) bool {
+  if v.Kind() == reflect.Pointer {
+    return v.IsNil()
+  }
+  if o, ok := v.Interface().(option.Optional); ok {
+    return o.IsNone()
+  }
+  switch v.Kind() {
+  case reflect.Slice, reflect.Map, reflect.String, reflect.Array:
+    return v.Len() == 0
+  }
+  return v.IsZero()
+}
@@ -0,0 +1,103 @@
+package optioncbor
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshal_RFCExamples(t *testing.T) {
+	tests := []struct {
+		value any
+		want  string
+	}{
+		{0, "00"},
+		{23, "17"},
+		{24, "1818"},
+		{100, "1864"},
+		{1000, "1903e8"},
+		{1000000, "1a000f4240"},
+		{uint64(math.MaxUint64), "1bffffffffffffffff"},
+		{-1, "20"},
+		{-1000, "3903e7"},
+		{int64(math.MinInt64), "3b7fffffffffffffff"},
+		{1.1, "fb3ff199999999999a"},
+		{float32(100000.0), "fa47c35000"},
+		{false, "f4"},
+		{true, "f5"},
+		{nil, "f6"},
+		{[]byte{1, 2, 3, 4}, "4401020304"},
+		{"", "60"},
+		{"IETF", "6449455446"},
+		{"ü", "62c3bc"},
+		{[]int{}, "80"},
+		{[]any{1, []int{2, 3}, []int{4, 5}}, "8301820203820405"},
+		{map[int]int{1: 2, 3: 4}, "a201020304"},
+		{map[string]any{"a": 1, "b": []int{2, 3}}, "a26161016162820203"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			data, err := Marshal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(data))
+		})
+	}
+}
+
+func TestMarshal_Option(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{"some", option.Some(1), "01"},
+		{"some string", option.Some("a"), "6161"},
+		{"none without error", option.None[int](nil), "f7"},
+		{"none with error", option.None[int](errors.New("sensor offline")), "f6"},
+		{"nested", option.Some(option.None[int](nil)), "f7"},
+		{"slice", []option.Option[int]{option.Some(1), option.None[int](nil)}, "8201f7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := Marshal(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, hex.EncodeToString(data))
+		})
+	}
+}
+
+func TestMarshal_Struct(t *testing.T) {
+	type reading struct {
+		Sensor  string                 `cbor:"s"`
+		Temp    option.Option[float32] `cbor:"t"`
+		Battery option.Option[int]     `cbor:"b,omitempty"`
+		Note    string                 `cbor:",omitempty"`
+		Skipped int                    `cbor:"-"`
+		private int
+	}
+
+	data, err := Marshal(reading{Sensor: "x", Temp: option.None[float32](nil), Skipped: 1, private: 2})
+	require.NoError(t, err)
+	// {"s": "x", "t": undefined}
+	assert.Equal(t, "a2617361786174f7", hex.EncodeToString(data))
+
+	data, err = Marshal(&reading{Sensor: "x", Battery: option.Some(5), Note: "n"})
+	require.NoError(t, err)
+	// {"s": "x", "t": undefined, "b": 5, "Note": "n"}
+	assert.Equal(t, "a4617361786174f7616205644e6f7465616e", hex.EncodeToString(data))
+}
+
+func TestMarshal_Unsupported(t *testing.T) {
+	_, err := Marshal(make(chan int))
+	assert.ErrorIs(t, err, ErrUnsupported)
+
+	_, err = Marshal(option.Some(1 + 2i))
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
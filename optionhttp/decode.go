@@ -0,0 +1,39 @@
+package optionhttp
+
+import (
+  "fmt"
+  "io"
+  "net/http"
+
+  "github.com/kalpio/option"
+)
+
+// MaxBodySize is the largest request body, in bytes, that DecodeJSON reads.
+var MaxBodySize int64 = 1 << 20
+
+// DecodeJSON reads the JSON body of r into a value of type S with
+// option.DecodeJSON, so fields tagged `option:"required"` are enforced and
+// every missing or malformed member is reported in one *option.DecodeError.
+// Bodies larger than MaxBodySize are rejected with an *http.MaxBytesError,
+// and w is told to close the connection once the limit is hit.
+//
+// The errors map to 400 Bad Request and 413 Content Too Large in Statuses,
+// and Respond lists the members of a *option.DecodeError in the problem body,
+// so a handler can pass them straight on:
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//		req, err := optionhttp.DecodeJSON[CreateUser](w, r)
+//		if err != nil {
+//			optionhttp.Respond(w, r, option.None[User](err))
+//			return
+//		}
+//		optionhttp.Respond(w, r, store.Create(req))
+//	}
+func DecodeJSON[S any](w http.ResponseWriter, r *http.Request) (S, error) {
+  body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+  if err != nil {
+    var zero S
+    return zero, fmt.Errorf("optionhttp: reading request body: %w", err)
+  }
+  return option.DecodeJSON[S](body)
+}
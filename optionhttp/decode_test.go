@@ -0,0 +1,82 @@
+package optionhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type createUser struct {
+	Name  option.Option[string] `json:"name" option:"required"`
+	Email option.Option[string] `json:"email" option:"required"`
+	Age   option.Option[int]    `json:"age"`
+}
+
+func createUserHandler(w http.ResponseWriter, r *http.Request) {
+	req, err := DecodeJSON[createUser](w, r)
+	if err != nil {
+		Respond(w, r, option.None[user](err))
+		return
+	}
+	Respond(w, r, option.Some(user{ID: req.Age.UnwrapOr(0), Name: req.Name.Unwrap()}))
+}
+
+func post(body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	createUserHandler(w, httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body)))
+	return w
+}
+
+func TestDecodeJSON(t *testing.T) {
+	w := post(`{"name":"gopher","email":"gopher@example.com","age":14}`)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"id":14,"name":"gopher"}`, w.Body.String())
+}
+
+func TestDecodeJSON_Invalid(t *testing.T) {
+	w := post(`{"name":"gopher","age":"ten"}`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	p := decodeProblem(t, w)
+	assert.Equal(t, "The request body is invalid.", p.Detail)
+	require.Len(t, p.Errors, 2)
+	assert.Equal(t, "/email", p.Errors[0].Pointer)
+	assert.Equal(t, "/email: missing required value", p.Errors[0].Detail)
+	assert.Equal(t, "/age", p.Errors[1].Pointer)
+}
+
+func TestDecodeJSON_Malformed(t *testing.T) {
+	w := post(`{"name":`)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	p := decodeProblem(t, w)
+	assert.Contains(t, p.Detail, "option: invalid JSON document")
+	assert.Empty(t, p.Errors)
+}
+
+func TestDecodeJSON_TooLarge(t *testing.T) {
+	old := MaxBodySize
+	MaxBodySize = 16
+	t.Cleanup(func() { MaxBodySize = old })
+
+	w := post(`{"name":"gopher","email":"gopher@example.com"}`)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
+
+func TestDecodeJSON_TooLargeClosesConnection(t *testing.T) {
+	old := MaxBodySize
+	MaxBodySize = 16
+	t.Cleanup(func() { MaxBodySize = old })
+
+	srv := httptest.NewServer(http.HandlerFunc(createUserHandler))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "application/json", strings.NewReader(`{"name":"gopher","email":"gopher@example.com"}`))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.True(t, resp.Close)
+}
@@ -0,0 +1,59 @@
+// Package optionhttp connects option.Option to net/http handlers.
+//
+// The request helpers read optional query parameters, headers, path values
+// and cookies as typed options:
+//
+//	func listItems(w http.ResponseWriter, r *http.Request) {
+//		limit := optionhttp.Query[int](r, "limit")
+//		if errors.Is(limit.Error(), optionhttp.ErrMalformed) {
+//			http.Error(w, limit.Error().Error(), http.StatusBadRequest)
+//			return
+//		}
+//		items := store.List(limit.UnwrapOr(50))
+//		...
+//	}
+//
+// A value that is not present in the request is None with an error matching
+// ErrMissing; a value that is present but cannot be parsed is None with an
+// error matching ErrMalformed. Both errors are *ParamError values that name
+// the offending parameter. Values are converted with option.Parse, so any type
+// it supports, including those added with option.RegisterParser, can be used.
+//
+// Respond writes an option back as the response: Some as a JSON body, None as
+// an RFC 9457 application/problem+json body whose status is derived from the
+// error by a configurable StatusMap.
+package optionhttp
+
+import (
+  "errors"
+  "fmt"
+)
+
+var (
+  ErrMissing   = errors.New("optionhttp: missing value")
+  ErrMalformed = errors.New("optionhttp: malformed value")
+)
+
+// ParamError describes a request parameter that is missing or malformed.
+// It matches ErrMissing or ErrMalformed with errors.Is.
+type ParamError struct {
+  Source string // where the parameter was read from, such as "query parameter"
+  Name   string // name of the parameter
+  Err    error  // ErrMissing, or the error returned by the parser
+}
+
+func (e *ParamError) Error() string {
+  if e.Err == ErrMissing {
+    return fmt.Sprintf("optionhttp: missing %s %q", e.Source, e.Name)
+  }
+  return fmt.Sprintf("optionhttp: malformed %s %q: %v", e.Source, e.Name, e.Err)
+}
+
+func (e *ParamError) Unwrap() error {
+  return e.Err
+}
+
+// Is reports whether a malformed parameter error matches ErrMalformed.
+func (e *ParamError) Is(target error) bool {
+  return target == ErrMalformed && e.Err != ErrMissing
+}
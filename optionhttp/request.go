@@ -0,0 +1,96 @@
+package optionhttp
+
+import (
+  "errors"
+  "net/http"
+
+  "github.com/kalpio/option"
+)
+
+// Query returns the first value of the query parameter key parsed as a T.
+// A parameter given without a value, as in ?q=, is present: it parses to
+// Some("") for strings and is malformed for most other types.
+//
+// Example:
+//
+//	// GET /items?limit=20&debug
+//	optionhttp.Query[int](r, "limit")  // Some(20)
+//	optionhttp.Query[int](r, "offset") // None, matches ErrMissing
+//	optionhttp.Query[bool](r, "debug") // None, matches ErrMalformed
+func Query[T any](r *http.Request, key string) option.Option[T] {
+  values, ok := r.URL.Query()[key]
+  if !ok || len(values) == 0 {
+    return missing[T]("query parameter", key)
+  }
+  return parse[T]("query parameter", key, values[0])
+}
+
+// Header returns the first value of the header key parsed as a T. The key is
+// canonicalized as by http.Header.Get.
+//
+// Example:
+//
+//	// X-Priority: 3
+//	optionhttp.Header[int](r, "x-priority")         // Some(3)
+//	optionhttp.Header[time.Time](r, "X-Not-Before") // None, matches ErrMissing
+func Header[T any](r *http.Request, key string) option.Option[T] {
+  values := r.Header.Values(key)
+  if len(values) == 0 {
+    return missing[T]("header", key)
+  }
+  return parse[T]("header", key, values[0])
+}
+
+// PathValue returns the path wildcard name of the request's route pattern,
+// as reported by http.Request.PathValue, parsed as a T. Because PathValue
+// does not distinguish an unknown wildcard from an empty match, an empty value
+// is reported as missing.
+//
+// Example:
+//
+//	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+//		id := optionhttp.PathValue[int64](r, "id")
+//		...
+//	})
+func PathValue[T any](r *http.Request, name string) option.Option[T] {
+  value := r.PathValue(name)
+  if value == "" {
+    return missing[T]("path value", name)
+  }
+  return parse[T]("path value", name, value)
+}
+
+// Cookie returns the named cookie of the request, or None matching ErrMissing
+// if the request does not carry a valid cookie with that name.
+//
+// Example:
+//
+//	session := optionhttp.Cookie(r, "session")
+//	if session.IsNone() {
+//		http.Redirect(w, r, "/login", http.StatusSeeOther)
+//		return
+//	}
+func Cookie(r *http.Request, name string) option.Option[*http.Cookie] {
+  c, err := r.Cookie(name)
+  if errors.Is(err, http.ErrNoCookie) {
+    return missing[*http.Cookie]("cookie", name)
+  }
+  if err != nil {
+    return option.None[*http.Cookie](&ParamError{Source: "cookie", Name: name, Err: err})
+  }
+  return option.Some(c)
+}
+
+// missing returns None with a ParamError wrapping ErrMissing.
+func missing[T any](source, name string) option.Option[T] {
+  return option.None[T](&ParamError{Source: source, Name: name, Err: ErrMissing})
+}
+
+// parse converts value with option.Parse, wrapping a failure in a ParamError.
+func parse[T any](source, name, value string) option.Option[T] {
+  o := option.Parse[T](value)
+  if err := o.Error(); o.IsNone() {
+    return option.None[T](&ParamError{Source: source, Name: name, Err: err})
+  }
+  return o
+}
@@ -0,0 +1,81 @@
+package optionhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuery(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/items?limit=20&limit=30&q=&debug&since=2024-06-01T10:00:00Z&page=two", nil)
+
+	assert.Equal(t, option.Some(20), Query[int](r, "limit"))
+	assert.Equal(t, option.Some(""), Query[string](r, "q"))
+	assert.Equal(t, option.Some(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)), Query[time.Time](r, "since"))
+
+	offset := Query[int](r, "offset")
+	assert.ErrorIs(t, offset.Error(), ErrMissing)
+	assert.NotErrorIs(t, offset.Error(), ErrMalformed)
+	assert.EqualError(t, offset.Error(), `optionhttp: missing query parameter "offset"`)
+	assert.Equal(t, 0, offset.UnwrapOr(0))
+
+	page := Query[int](r, "page")
+	assert.ErrorIs(t, page.Error(), ErrMalformed)
+	assert.NotErrorIs(t, page.Error(), ErrMissing)
+	var paramErr *ParamError
+	require.ErrorAs(t, page.Error(), &paramErr)
+	assert.Equal(t, "query parameter", paramErr.Source)
+	assert.Equal(t, "page", paramErr.Name)
+
+	assert.ErrorIs(t, Query[bool](r, "debug").Error(), ErrMalformed)
+}
+
+func TestHeader(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("X-Priority", "3")
+	r.Header.Set("X-Forwarded-For", "10.0.0.1")
+	r.Header.Set("X-Retries", "many")
+
+	assert.Equal(t, option.Some(3), Header[int](r, "x-priority"))
+	assert.Equal(t, option.Some(netip.MustParseAddr("10.0.0.1")), Header[netip.Addr](r, "X-Forwarded-For"))
+	assert.ErrorIs(t, Header[int](r, "X-Missing").Error(), ErrMissing)
+
+	retries := Header[int](r, "X-Retries")
+	assert.ErrorIs(t, retries.Error(), ErrMalformed)
+	assert.Contains(t, retries.Error().Error(), `optionhttp: malformed header "X-Retries"`)
+}
+
+func TestPathValue(t *testing.T) {
+	var id, name, missing option.Option[int64]
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /users/{id}/{name}", func(w http.ResponseWriter, r *http.Request) {
+		id = PathValue[int64](r, "id")
+		name = PathValue[int64](r, "name")
+		missing = PathValue[int64](r, "group")
+	})
+
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42/gopher", nil))
+
+	assert.Equal(t, option.Some[int64](42), id)
+	assert.ErrorIs(t, name.Error(), ErrMalformed)
+	assert.ErrorIs(t, missing.Error(), ErrMissing)
+}
+
+func TestCookie(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(&http.Cookie{Name: "session", Value: "abc123"})
+
+	session := Cookie(r, "session")
+	require.True(t, session.IsSome())
+	assert.Equal(t, "abc123", session.Unwrap().Value)
+
+	theme := Cookie(r, "theme")
+	assert.ErrorIs(t, theme.Error(), ErrMissing)
+	assert.EqualError(t, theme.Error(), `optionhttp: missing cookie "theme"`)
+}
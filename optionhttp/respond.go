@@ -0,0 +1,168 @@
+package optionhttp
+
+import (
+  "encoding/json"
+  "errors"
+  "io/fs"
+  "net/http"
+
+  "github.com/kalpio/option"
+)
+
+// Problem is an RFC 9457 problem details object, written by Respond as an
+// application/problem+json body for None options.
+type Problem struct {
+  Type     string `json:"type"`
+  Title    string `json:"title"`
+  Status   int    `json:"status"`
+  Detail   string `json:"detail,omitempty"`
+  Instance string `json:"instance,omitempty"`
+
+  // Errors lists the members of a request body rejected by DecodeJSON.
+  Errors []ProblemField `json:"errors,omitempty"`
+}
+
+// ProblemField describes one member of a request body in a Problem.
+type ProblemField struct {
+  Pointer string `json:"pointer"`
+  Detail  string `json:"detail"`
+}
+
+// StatusMap maps the errors of None options to HTTP status codes. Rules are
+// tried from the most recently added to the oldest, so later rules override
+// earlier ones, and the first match decides the status. Errors that match no
+// rule get the Fallback status.
+//
+// A StatusMap should be configured before it is used to serve requests; it is
+// not safe to add rules concurrently with Status.
+type StatusMap struct {
+  rules []func(error) bool
+  codes []int
+
+  // NoError is the status of None options without an error.
+  NoError int
+
+  // Fallback is the status of errors that match no rule.
+  Fallback int
+}
+
+// Statuses is the StatusMap used by Respond. It maps None options without an
+// error, option.ErrKeyNotFound, option.ErrAbsent and fs.ErrNotExist to 404 Not
+// Found, ErrMissing, ErrMalformed and option.ErrInvalidJSON to 400 Bad Request,
+// *http.MaxBytesError to 413 Content Too Large, and any other error to 500
+// Internal Server Error.
+//
+// Example:
+//
+//	optionhttp.Statuses.Is(store.ErrNotFound, http.StatusNotFound)
+//	optionhttp.As[*store.ConflictError](optionhttp.Statuses, http.StatusConflict)
+var Statuses = defaultStatuses()
+
+// defaultStatuses returns the rules that Statuses starts with.
+func defaultStatuses() *StatusMap {
+  m := NewStatusMap().
+    Is(option.ErrKeyNotFound, http.StatusNotFound).
+    Is(option.ErrAbsent, http.StatusNotFound).
+    Is(fs.ErrNotExist, http.StatusNotFound).
+    Is(ErrMissing, http.StatusBadRequest).
+    Is(ErrMalformed, http.StatusBadRequest).
+    Is(option.ErrInvalidJSON, http.StatusBadRequest)
+  return As[*http.MaxBytesError](m, http.StatusRequestEntityTooLarge)
+}
+
+// NewStatusMap returns an empty StatusMap that reports None options without an
+// error as 404 Not Found and every other error as 500 Internal Server Error.
+func NewStatusMap() *StatusMap {
+  return &StatusMap{NoError: http.StatusNotFound, Fallback: http.StatusInternalServerError}
+}
+
+// Is adds a rule mapping errors that match target with errors.Is to status.
+// It returns m so calls can be chained.
+func (m *StatusMap) Is(target error, status int) *StatusMap {
+  return m.add(func(err error) bool { return errors.Is(err, target) }, status)
+}
+
+// As adds a rule to m mapping errors that match the error type E with
+// errors.As to status. It returns m so calls can be chained.
+func As[E error](m *StatusMap, status int) *StatusMap {
+  return m.add(func(err error) bool {
+    var target E
+    return errors.As(err, &target)
+  }, status)
+}
+
+func (m *StatusMap) add(match func(error) bool, status int) *StatusMap {
+  m.rules = append(m.rules, match)
+  m.codes = append(m.codes, status)
+  return m
+}
+
+// Status returns the status code for a None option carrying err.
+func (m *StatusMap) Status(err error) int {
+  if err == nil {
+    return m.NoError
+  }
+  for i := len(m.rules) - 1; i >= 0; i-- {
+    if m.rules[i](err) {
+      return m.codes[i]
+    }
+  }
+  return m.Fallback
+}
+
+// Respond writes opt as the response to r, using Statuses to pick the status
+// of None options. It is shorthand for RespondWith(Statuses, w, r, opt).
+//
+// Example:
+//
+//	mux.HandleFunc("GET /users/{id}", func(w http.ResponseWriter, r *http.Request) {
+//		optionhttp.Respond(w, r, option.FlatMap(optionhttp.PathValue[int64](r, "id"), store.User))
+//	})
+func Respond[T any](w http.ResponseWriter, r *http.Request, opt option.Option[T]) {
+  RespondWith(Statuses, w, r, opt)
+}
+
+// RespondWith writes opt as the response to r. A Some option is written as
+// 200 OK with the JSON encoding of its value. A None option is written as an
+// application/problem+json Problem whose status is given by m. The error
+// message is included as the problem detail for 4xx statuses only, so internal
+// errors are not leaked to clients; the members of a *option.DecodeError are
+// also listed in the errors member of the problem.
+func RespondWith[T any](m *StatusMap, w http.ResponseWriter, r *http.Request, opt option.Option[T]) {
+  if opt.IsNone() {
+    writeProblem(w, r, m.Status(opt.Error()), opt.Error())
+    return
+  }
+  body, err := json.Marshal(opt.Unwrap())
+  if err != nil {
+    writeProblem(w, r, http.StatusInternalServerError, err)
+    return
+  }
+  w.Header().Set("Content-Type", "application/json")
+  w.WriteHeader(http.StatusOK)
+  w.Write(append(body, '\n'))
+}
+
+// writeProblem writes an application/problem+json response for err.
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, err error) {
+  problem := Problem{
+    Type:     "about:blank",
+    Title:    http.StatusText(status),
+    Status:   status,
+    Instance: r.URL.Path,
+  }
+  if err != nil && status >= 400 && status < 500 {
+    problem.Detail = err.Error()
+    var decodeErr *option.DecodeError
+    if errors.As(err, &decodeErr) {
+      problem.Detail = "The request body is invalid."
+      for _, f := range decodeErr.Fields {
+        problem.Errors = append(problem.Errors, ProblemField{Pointer: f.Pointer, Detail: f.Error()})
+      }
+    }
+  }
+  body, _ := json.Marshal(problem)
+  w.Header().Set("Content-Type", "application/problem+json")
+  w.WriteHeader(status)
+  w.Write(append(body, '\n'))
+}
@@ -0,0 +1,110 @@
+package optionhttp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type user struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type conflictError struct{ id int }
+
+func (e *conflictError) Error() string { return fmt.Sprintf("user %d already exists", e.id) }
+
+func respond[T any](m *StatusMap, opt option.Option[T]) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	RespondWith(m, w, httptest.NewRequest(http.MethodGet, "/users/7", nil), opt)
+	return w
+}
+
+func decodeProblem(t *testing.T, w *httptest.ResponseRecorder) Problem {
+	t.Helper()
+	assert.Equal(t, "application/problem+json", w.Header().Get("Content-Type"))
+	var p Problem
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &p))
+	return p
+}
+
+func TestRespond_Some(t *testing.T) {
+	w := httptest.NewRecorder()
+	Respond(w, httptest.NewRequest(http.MethodGet, "/users/7", nil), option.Some(user{ID: 7, Name: "gopher"}))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"id":7,"name":"gopher"}`, w.Body.String())
+}
+
+func TestRespond_None(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{"no error", nil, http.StatusNotFound, ""},
+		{"key not found", fmt.Errorf("user 7: %w", option.ErrKeyNotFound), http.StatusNotFound, "user 7: option: key not found"},
+		{"missing", &ParamError{Source: "query parameter", Name: "id", Err: ErrMissing}, http.StatusBadRequest, `optionhttp: missing query parameter "id"`},
+		{"internal", errors.New("database is down"), http.StatusInternalServerError, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			Respond(w, httptest.NewRequest(http.MethodGet, "/users/7", nil), option.None[user](tt.err))
+
+			assert.Equal(t, tt.status, w.Code)
+			assert.Equal(t, Problem{
+				Type:     "about:blank",
+				Title:    http.StatusText(tt.status),
+				Status:   tt.status,
+				Detail:   tt.detail,
+				Instance: "/users/7",
+			}, decodeProblem(t, w))
+		})
+	}
+}
+
+func TestRespond_ProblemBody(t *testing.T) {
+	w := respond(NewStatusMap(), option.None[user](nil))
+	assert.JSONEq(t, `{"type":"about:blank","title":"Not Found","status":404,"instance":"/users/7"}`, w.Body.String())
+}
+
+func TestStatusMap(t *testing.T) {
+	errGone := errors.New("gone")
+	m := NewStatusMap().Is(errGone, http.StatusGone)
+	As[*conflictError](m, http.StatusConflict)
+
+	assert.Equal(t, http.StatusNotFound, m.Status(nil))
+	assert.Equal(t, http.StatusGone, m.Status(fmt.Errorf("wrapped: %w", errGone)))
+	assert.Equal(t, http.StatusConflict, m.Status(fmt.Errorf("wrapped: %w", &conflictError{id: 7})))
+	assert.Equal(t, http.StatusInternalServerError, m.Status(errors.New("other")))
+
+	m.Is(errGone, http.StatusNotFound)
+	assert.Equal(t, http.StatusNotFound, m.Status(errGone), "later rules take precedence")
+
+	m.NoError = http.StatusNoContent
+	m.Fallback = http.StatusServiceUnavailable
+	assert.Equal(t, http.StatusNoContent, m.Status(nil))
+	assert.Equal(t, http.StatusServiceUnavailable, m.Status(errors.New("other")))
+
+	w := respond(m, option.None[user](&conflictError{id: 7}))
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Equal(t, "user 7 already exists", decodeProblem(t, w).Detail)
+}
+
+func TestRespond_MarshalError(t *testing.T) {
+	w := respond(NewStatusMap(), option.Some(make(chan int)))
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Empty(t, decodeProblem(t, w).Detail)
+}
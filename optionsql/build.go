@@ -0,0 +1,174 @@
+package optionsql
+
+import (
+  "database/sql"
+  "database/sql/driver"
+  "errors"
+  "fmt"
+  "reflect"
+  "strconv"
+  "strings"
+
+  "github.com/kalpio/option"
+)
+
+var (
+  ErrNoFields = errors.New("optionsql: no fields to write")
+)
+
+// Placeholder is a style of bind parameter placeholder.
+type Placeholder uint8
+
+const (
+  Question Placeholder = iota // ?, as used by MySQL and SQLite
+  Dollar                      // $1, $2, ..., as used by PostgreSQL
+  Named                       // :name, with sql.NamedArg arguments
+)
+
+// Builder builds SQL fragments for INSERT and UPDATE statements from structs
+// of option.Option fields, leaving out the fields that are None. Fields are
+// matched to columns as in ScanStruct.
+//
+// Option fields are written when they are Some, and Nullable fields when they
+// are not absent, so a null Nullable sets its column to NULL. Any other field
+// is always written.
+//
+// A Builder collects the arguments of every fragment it produces, numbering
+// Dollar placeholders across them, so the fragments of one statement must
+// come from the same Builder. The zero value uses the Question style.
+//
+// Example:
+//
+//	type UserPatch struct {
+//		Name  option.Option[string]   `db:"name"`
+//		Email option.Nullable[string] `db:"email"`
+//		Age   option.Option[int]      `db:"age"`
+//	}
+//
+//	b := optionsql.NewBuilder(optionsql.Dollar)
+//	set, err := b.Set(UserPatch{Name: option.Some("gopher"), Email: option.Null[string]()})
+//	query := "UPDATE users SET " + set + " WHERE id = " + b.Arg("id", 42)
+//	// UPDATE users SET name = $1, email = $2 WHERE id = $3
+//	_, err = db.Exec(query, b.Args()...)
+type Builder struct {
+  style Placeholder
+  args  []any
+}
+
+// NewBuilder returns a Builder using the given placeholder style.
+func NewBuilder(style Placeholder) *Builder {
+  return &Builder{style: style}
+}
+
+// Args returns the arguments of all fragments built so far, in placeholder
+// order. With the Named style they are sql.NamedArg values.
+func (b *Builder) Args() []any {
+  return b.args
+}
+
+// Arg adds value as an argument and returns its placeholder. The name is used
+// by the Named style only.
+func (b *Builder) Arg(name string, value any) string {
+  if b.style == Named {
+    b.args = append(b.args, sql.Named(name, value))
+    return ":" + name
+  }
+  b.args = append(b.args, value)
+  if b.style == Dollar {
+    return "$" + strconv.Itoa(len(b.args))
+  }
+  return "?"
+}
+
+// Set returns the assignments of an UPDATE statement for the fields of the
+// struct v that are written, such as "name = ?, age = ?". It returns
+// ErrNoFields if there are none, as an empty SET clause is not valid SQL.
+func (b *Builder) Set(v any) (string, error) {
+  columns, values, err := b.collect(v)
+  if err != nil {
+    return "", err
+  }
+  var sb strings.Builder
+  for i, c := range columns {
+    if i > 0 {
+      sb.WriteString(", ")
+    }
+    sb.WriteString(c)
+    sb.WriteString(" = ")
+    sb.WriteString(values[i])
+  }
+  return sb.String(), nil
+}
+
+// Insert returns the column list and the VALUES list of an INSERT statement
+// for the fields of the struct v that are written, such as "(name, age)" and
+// "(?, ?)". It returns ErrNoFields if there are none.
+//
+// Example:
+//
+//	b := optionsql.NewBuilder(optionsql.Question)
+//	columns, values, err := b.Insert(user)
+//	query := "INSERT INTO users " + columns + " VALUES " + values
+func (b *Builder) Insert(v any) (columns, values string, err error) {
+  cols, vals, err := b.collect(v)
+  if err != nil {
+    return "", "", err
+  }
+  return "(" + strings.Join(cols, ", ") + ")", "(" + strings.Join(vals, ", ") + ")", nil
+}
+
+// collect adds the written fields of v as arguments and returns their
+// columns and placeholders. No arguments are added on error.
+func (b *Builder) collect(v any) (columns, placeholders []string, err error) {
+  rv := reflect.ValueOf(v)
+  if rv.Kind() == reflect.Pointer && !rv.IsNil() {
+    rv = rv.Elem()
+  }
+  if rv.Kind() != reflect.Struct {
+    return nil, nil, fmt.Errorf("optionsql: Builder needs a struct, got %T", v)
+  }
+
+  type column struct {
+    name  string
+    value any
+  }
+  var cols []column
+  for _, f := range planOf(rv.Type()).fields {
+    value, ok, err := writeValue(rv.FieldByIndex(f.index))
+    if err != nil {
+      return nil, nil, fmt.Errorf("optionsql: %s: %w", f.path, err)
+    }
+    if ok {
+      cols = append(cols, column{f.name, value})
+    }
+  }
+  if len(cols) == 0 {
+    return nil, nil, fmt.Errorf("%w in %s", ErrNoFields, rv.Type())
+  }
+
+  for _, c := range cols {
+    columns = append(columns, c.name)
+    placeholders = append(placeholders, b.Arg(c.name, c.value))
+  }
+  return columns, placeholders, nil
+}
+
+// writeValue returns the argument for the field v, and false if the field is
+// left out.
+func writeValue(v reflect.Value) (any, bool, error) {
+  switch x := v.Interface().(type) {
+  case option.Optional:
+    value, ok := x.Interface()
+    return value, ok, nil
+  case interface {
+    IsAbsent() bool
+    driver.Valuer
+  }:
+    if x.IsAbsent() {
+      return nil, false, nil
+    }
+    value, err := x.Value()
+    return value, true, err
+  }
+  return v.Interface(), true, nil
+}
@@ -0,0 +1,146 @@
+package optionsql
+
+import (
+	"database/sql"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type userPatch struct {
+	Name     option.Option[string]    `db:"name"`
+	Email    option.Nullable[string]  `db:"email"`
+	Age      option.Option[int]       `db:"age"`
+	Seen     option.Option[time.Time] `db:"last_seen"`
+	Internal option.Option[string]    `db:"-"`
+}
+
+type newUser struct {
+	ID    int64                 `db:"id"`
+	Name  string                `db:"name"`
+	Email option.Option[string] `db:"email"`
+	Age   option.Option[int]    `db:"age"`
+}
+
+type baseRow struct {
+	ID   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+// namedRow shadows the name column promoted from baseRow.
+type namedRow struct {
+	baseRow
+	Name option.Option[string] `db:"name"`
+}
+
+var styles = []struct {
+	name  string
+	style Placeholder
+}{
+	{"question", Question},
+	{"dollar", Dollar},
+	{"named", Named},
+}
+
+// render formats a query and its arguments for a golden file.
+func render(query string, args []any) string {
+	var sb strings.Builder
+	sb.WriteString(query + "\n")
+	for _, a := range args {
+		if na, ok := a.(sql.NamedArg); ok {
+			fmt.Fprintf(&sb, "  %s: %#v\n", na.Name, na.Value)
+			continue
+		}
+		fmt.Fprintf(&sb, "  %#v\n", a)
+	}
+	return sb.String()
+}
+
+func assertGolden(t *testing.T, name, got string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	if *update {
+		require.NoError(t, os.MkdirAll("testdata", 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(got), 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), got)
+}
+
+func TestBuilder_Update(t *testing.T) {
+	patch := userPatch{
+		Name:     option.Some("gopher"),
+		Email:    option.Null[string](),
+		Seen:     option.Some(time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)),
+		Internal: option.Some("ignored"),
+	}
+
+	for _, s := range styles {
+		t.Run(s.name, func(t *testing.T) {
+			b := NewBuilder(s.style)
+			set, err := b.Set(patch)
+			require.NoError(t, err)
+			query := "UPDATE users SET " + set + " WHERE id = " + b.Arg("id", 42)
+			assertGolden(t, "update_"+s.name, render(query, b.Args()))
+		})
+	}
+}
+
+func TestBuilder_Insert(t *testing.T) {
+	u := &newUser{ID: 7, Name: "gopher", Age: option.Some(14)}
+
+	for _, s := range styles {
+		t.Run(s.name, func(t *testing.T) {
+			b := NewBuilder(s.style)
+			columns, values, err := b.Insert(u)
+			require.NoError(t, err)
+			query := "INSERT INTO users " + columns + " VALUES " + values
+			assertGolden(t, "insert_"+s.name, render(query, b.Args()))
+		})
+	}
+}
+
+func TestBuilder_InsertShadowed(t *testing.T) {
+	row := namedRow{baseRow: baseRow{ID: 7, Name: "hidden"}, Name: option.Some("gopher")}
+
+	for _, s := range styles {
+		t.Run(s.name, func(t *testing.T) {
+			b := NewBuilder(s.style)
+			columns, values, err := b.Insert(row)
+			require.NoError(t, err)
+			query := "INSERT INTO users " + columns + " VALUES " + values
+			assertGolden(t, "insert_shadowed_"+s.name, render(query, b.Args()))
+		})
+	}
+}
+
+func TestBuilder_ZeroValue(t *testing.T) {
+	var b Builder
+	set, err := b.Set(userPatch{Age: option.Some(3)})
+	require.NoError(t, err)
+	assert.Equal(t, "age = ?", set)
+	assert.Equal(t, []any{3}, b.Args())
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	b := NewBuilder(Dollar)
+	_, err := b.Set(userPatch{Email: option.Absent[string]()})
+	assert.ErrorIs(t, err, ErrNoFields)
+	assert.Empty(t, b.Args())
+
+	_, _, err = b.Insert(42)
+	assert.Error(t, err)
+
+	assert.Equal(t, "$1", b.Arg("id", 1))
+}
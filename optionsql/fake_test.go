@@ -0,0 +1,71 @@
+package optionsql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"testing"
+)
+
+// fakeResult is the result set a fakeConn returns for a query.
+type fakeResult struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// fakeConnector serves canned result sets keyed by query text.
+type fakeConnector struct {
+	results map[string]fakeResult
+}
+
+func (c *fakeConnector) Connect(context.Context) (driver.Conn, error) { return &fakeConn{c}, nil }
+func (c *fakeConnector) Driver() driver.Driver                        { return fakeDriver{} }
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return nil, errors.New("fake: use OpenDB") }
+
+type fakeConn struct {
+	c *fakeConnector
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("fake: not supported")
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("fake: not supported") }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	res, ok := c.c.results[query]
+	if !ok {
+		return nil, errors.New("fake: unknown query " + query)
+	}
+	return &fakeRows{res: res}, nil
+}
+
+type fakeRows struct {
+	res fakeResult
+	pos int
+}
+
+func (r *fakeRows) Columns() []string { return r.res.columns }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.res.rows) {
+		return io.EOF
+	}
+	copy(dest, r.res.rows[r.pos])
+	r.pos++
+	return nil
+}
+
+// openFake returns a database serving results.
+func openFake(t *testing.T, results map[string]fakeResult) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(&fakeConnector{results: results})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
@@ -0,0 +1,235 @@
+// Package optionsql maps database rows to and from structs of option.Option
+// fields.
+//
+// Columns are matched to struct fields by their `db` tag, or case-insensitively
+// by field name for untagged fields; `db:"-"` skips a field. Fields of
+// embedded structs without a db tag are promoted, as with encoding/json.
+//
+//	type User struct {
+//		ID    int64                    `db:"id"`
+//		Name  string                   `db:"name"`
+//		Email option.Option[string]    `db:"email"`
+//		Seen  option.Option[time.Time] `db:"last_seen"`
+//	}
+//
+//	rows, err := db.Query("SELECT id, name, email, last_seen FROM users")
+//	users, err := optionsql.ScanAll[User](rows)
+//
+// NULL columns are scanned into Option fields as None. Pointers, Nullables
+// and types implementing sql.Scanner handle NULL themselves; a NULL in any
+// other field is reported as an error wrapping ErrNull that names the column
+// and the field, instead of database/sql's generic conversion error.
+//
+// In the other direction, a Builder produces the SET clause of an UPDATE or the
+// column and VALUES lists of an INSERT from the fields that are Some, which is
+// what partial updates need.
+package optionsql
+
+import (
+  "database/sql"
+  "errors"
+  "fmt"
+  "reflect"
+  "strings"
+  "sync"
+)
+
+var (
+  ErrNull          = errors.New("optionsql: NULL in a field that is not nullable")
+  ErrUnknownColumn = errors.New("optionsql: no field for column")
+  ErrInvalidTarget = errors.New("optionsql: destination must be a non-nil pointer to a struct")
+)
+
+// field is a struct field that can receive or provide a column.
+type field struct {
+  name  string
+  path  string
+  index []int
+
+  // nullable is set for fields that accept NULL when scanned into
+  // directly: options, nullables, pointers and sql.Scanners.
+  nullable bool
+}
+
+// plan lists the db fields of a struct type in field order.
+type plan struct {
+  fields []field
+  byName map[string]int
+}
+
+var planCache sync.Map
+
+var scannerType = reflect.TypeFor[sql.Scanner]()
+
+// planOf returns the cached plan for the struct type t.
+func planOf(t reflect.Type) *plan {
+  if cached, ok := planCache.Load(t); ok {
+    return cached.(*plan)
+  }
+
+  p := &plan{byName: map[string]int{}}
+  var walk func(t reflect.Type, prefix []int, path string)
+  walk = func(t reflect.Type, prefix []int, path string) {
+    for i := 0; i < t.NumField(); i++ {
+      sf := t.Field(i)
+      name, tagged := sf.Tag.Lookup("db")
+      if name == "-" {
+        continue
+      }
+      index := append(append([]int(nil), prefix...), i)
+      if sf.Anonymous && !tagged && sf.Type.Kind() == reflect.Struct {
+        walk(sf.Type, index, path+sf.Name+".")
+        continue
+      }
+      if !sf.IsExported() {
+        continue
+      }
+      if name == "" {
+        name = sf.Name
+      }
+      f := field{
+        name:     name,
+        path:     path + sf.Name,
+        index:    index,
+        nullable: sf.Type.Kind() == reflect.Pointer || reflect.PointerTo(sf.Type).Implements(scannerType),
+      }
+      key := strings.ToLower(name)
+      if j, ok := p.byName[key]; ok {
+        // Outer fields shadow promoted ones, whichever comes first.
+        if len(prefix) == 0 {
+          p.fields[j] = f
+        }
+        continue
+      }
+      p.byName[key] = len(p.fields)
+      p.fields = append(p.fields, f)
+    }
+  }
+  walk(t, nil, t.Name()+".")
+
+  cached, _ := planCache.LoadOrStore(t, p)
+  return cached.(*plan)
+}
+
+// lookup returns the field for a column name.
+func (p *plan) lookup(column string) (field, bool) {
+  i, ok := p.byName[strings.ToLower(column)]
+  if !ok {
+    return field{}, false
+  }
+  return p.fields[i], true
+}
+
+// scanner scans rows with a fixed set of columns into structs of one type.
+type scanner struct {
+  columns []string
+  fields  []field
+  dests   []any
+}
+
+func newScanner(rows *sql.Rows, t reflect.Type) (*scanner, error) {
+  columns, err := rows.Columns()
+  if err != nil {
+    return nil, err
+  }
+  p := planOf(t)
+  s := &scanner{columns: columns, fields: make([]field, len(columns)), dests: make([]any, len(columns))}
+  for i, c := range columns {
+    f, ok := p.lookup(c)
+    if !ok {
+      return nil, fmt.Errorf("%w %q in %s", ErrUnknownColumn, c, t)
+    }
+    s.fields[i] = f
+  }
+  return s, nil
+}
+
+// scan scans the current row into the struct v.
+func (s *scanner) scan(rows *sql.Rows, v reflect.Value) error {
+  for i, f := range s.fields {
+    fv := v.FieldByIndex(f.index)
+    if f.nullable {
+      s.dests[i] = fv.Addr().Interface()
+    } else {
+      // Scanning into a pointer lets database/sql report NULL as nil
+      // rather than as a conversion error.
+      s.dests[i] = reflect.New(fv.Addr().Type()).Interface()
+    }
+  }
+  if err := rows.Scan(s.dests...); err != nil {
+    return err
+  }
+
+  for i, f := range s.fields {
+    if f.nullable {
+      continue
+    }
+    ptr := reflect.ValueOf(s.dests[i]).Elem()
+    if ptr.IsNil() {
+      return fmt.Errorf("%w: column %q is NULL, but %s is a %s", ErrNull, s.columns[i], f.path, ptr.Type().Elem())
+    }
+    v.FieldByIndex(f.index).Set(ptr.Elem())
+  }
+  return nil
+}
+
+// ScanStruct scans the current row of rows into the struct dst points to.
+// Like rows.Scan, it must be called after a successful call to rows.Next.
+// Every column must match a field of the struct.
+//
+// Example:
+//
+//	for rows.Next() {
+//		var u User
+//		if err := optionsql.ScanStruct(rows, &u); err != nil {
+//			return err
+//		}
+//		...
+//	}
+func ScanStruct(rows *sql.Rows, dst any) error {
+  v := reflect.ValueOf(dst)
+  if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+    return fmt.Errorf("%w, got %T", ErrInvalidTarget, dst)
+  }
+  s, err := newScanner(rows, v.Elem().Type())
+  if err != nil {
+    return err
+  }
+  return s.scan(rows, v.Elem())
+}
+
+// ScanAll scans every remaining row of rows into a T, which must be a struct
+// type, and closes rows.
+//
+// Example:
+//
+//	rows, err := db.QueryContext(ctx, "SELECT * FROM users WHERE active")
+//	if err != nil {
+//		return err
+//	}
+//	users, err := optionsql.ScanAll[User](rows)
+func ScanAll[T any](rows *sql.Rows) ([]T, error) {
+  defer rows.Close()
+
+  t := reflect.TypeFor[T]()
+  if t.Kind() != reflect.Struct {
+    return nil, fmt.Errorf("%w, got %s", ErrInvalidTarget, t)
+  }
+  s, err := newScanner(rows, t)
+  if err != nil {
+    return nil, err
+  }
+
+  var out []T
+  for rows.Next() {
+    var row T
+    if err := s.scan(rows, reflect.ValueOf(&row).Elem()); err != nil {
+      return nil, err
+    }
+    out = append(out, row)
+  }
+  if err := rows.Err(); err != nil {
+    return nil, err
+  }
+  return out, rows.Close()
+}
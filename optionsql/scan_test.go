@@ -0,0 +1,127 @@
+package optionsql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type audit struct {
+	CreatedAt time.Time                `db:"created_at"`
+	DeletedAt option.Option[time.Time] `db:"deleted_at"`
+}
+
+type user struct {
+	audit
+	ID       int64                   `db:"id"`
+	Name     string                  `db:"name"`
+	Email    option.Option[string]   `db:"email"`
+	Age      option.Option[int]      `db:"age"`
+	Nickname option.Nullable[string] `db:"nickname"`
+	Manager  *int64                  `db:"manager_id"`
+	Note     sql.NullString          `db:"note"`
+	Score    float64
+	Secret   string `db:"-"`
+}
+
+var created = time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+var userResults = map[string]fakeResult{
+	"users": {
+		columns: []string{"id", "name", "email", "age", "nickname", "manager_id", "note", "SCORE", "created_at", "deleted_at"},
+		rows: [][]driver.Value{
+			{int64(1), "gopher", "gopher@example.com", int64(14), "go", int64(7), "hi", 1.5, created, nil},
+			{int64(2), []byte("ferris"), nil, nil, nil, nil, nil, 0.0, created, created},
+		},
+	},
+	"null name": {
+		columns: []string{"id", "name"},
+		rows:    [][]driver.Value{{int64(3), nil}},
+	},
+	"unknown": {
+		columns: []string{"id", "password"},
+		rows:    [][]driver.Value{{int64(3), "hunter2"}},
+	},
+}
+
+func TestScanAll(t *testing.T) {
+	db := openFake(t, userResults)
+	rows, err := db.Query("users")
+	require.NoError(t, err)
+
+	users, err := ScanAll[user](rows)
+	require.NoError(t, err)
+	manager := int64(7)
+	assert.Equal(t, []user{
+		{
+			audit:    audit{CreatedAt: created},
+			ID:       1,
+			Name:     "gopher",
+			Email:    option.Some("gopher@example.com"),
+			Age:      option.Some(14),
+			Nickname: option.Value("go"),
+			Manager:  &manager,
+			Note:     sql.NullString{String: "hi", Valid: true},
+			Score:    1.5,
+		},
+		{
+			audit:    audit{CreatedAt: created, DeletedAt: option.Some(created)},
+			ID:       2,
+			Name:     "ferris",
+			Nickname: option.Null[string](),
+		},
+	}, users)
+}
+
+func TestScanStruct(t *testing.T) {
+	db := openFake(t, userResults)
+	rows, err := db.Query("users")
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []string
+	for rows.Next() {
+		var u user
+		require.NoError(t, ScanStruct(rows, &u))
+		got = append(got, u.Name+":"+u.Email.UnwrapOr("-"))
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"gopher:gopher@example.com", "ferris:-"}, got)
+}
+
+func TestScan_NullInPlainField(t *testing.T) {
+	db := openFake(t, userResults)
+	rows, err := db.Query("null name")
+	require.NoError(t, err)
+
+	_, err = ScanAll[user](rows)
+	assert.ErrorIs(t, err, ErrNull)
+	assert.EqualError(t, err, `optionsql: NULL in a field that is not nullable: column "name" is NULL, but user.Name is a string`)
+}
+
+func TestScan_Errors(t *testing.T) {
+	db := openFake(t, userResults)
+
+	rows, err := db.Query("unknown")
+	require.NoError(t, err)
+	_, err = ScanAll[user](rows)
+	assert.ErrorIs(t, err, ErrUnknownColumn)
+
+	rows, err = db.Query("users")
+	require.NoError(t, err)
+	defer rows.Close()
+	require.True(t, rows.Next())
+	var n int
+	assert.ErrorIs(t, ScanStruct(rows, &n), ErrInvalidTarget)
+	assert.ErrorIs(t, ScanStruct(rows, (*user)(nil)), ErrInvalidTarget)
+
+	rows2, err := db.Query("users")
+	require.NoError(t, err)
+	_, err = ScanAll[int](rows2)
+	assert.ErrorIs(t, err, ErrInvalidTarget)
+}
@@ -0,0 +1,81 @@
+// Package optionyaml adds gopkg.in/yaml.v3 support to option.Option, so that
+// the option package itself does not depend on a YAML library.
+//
+// Use Option in place of option.Option for fields of YAML documents. It embeds
+// the option.Option, so the usual methods are available on it directly:
+//
+//	type Config struct {
+//		Host optionyaml.Option[string] `yaml:"host"`
+//		Port optionyaml.Option[int]    `yaml:"port,omitempty"`
+//	}
+//
+//	var cfg Config
+//	err := yaml.Unmarshal(data, &cfg)
+//	addr := fmt.Sprintf("%s:%d", cfg.Host.UnwrapOr("localhost"), cfg.Port.UnwrapOr(8080))
+package optionyaml
+
+import (
+  "fmt"
+
+  "github.com/kalpio/option"
+  "gopkg.in/yaml.v3"
+)
+
+// Option is an option.Option that implements the gopkg.in/yaml.v3 Marshaler
+// and Unmarshaler interfaces. Its zero value is None.
+type Option[T any] struct {
+  option.Option[T]
+}
+
+// Wrap returns o as an Option.
+func Wrap[T any](o option.Option[T]) Option[T] {
+  return Option[T]{o}
+}
+
+var (
+  _ yaml.Marshaler   = Option[int]{}
+  _ yaml.Unmarshaler = (*Option[int])(nil)
+)
+
+// MarshalYAML implements yaml.Marshaler. A Some option is encoded as its
+// contained value and a None option as null. Use the omitempty tag option to
+// leave None fields out entirely.
+//
+// Example:
+//
+//	out, _ := yaml.Marshal(Config{Host: optionyaml.Wrap(option.Some("localhost"))})
+//	// host: localhost
+func (o Option[T]) MarshalYAML() (any, error) {
+  if o.IsNone() {
+    return nil, nil
+  }
+  return o.Unwrap(), nil
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler. Any node other than null is
+// decoded into the contained value; decode errors report the line and column
+// of the offending node.
+//
+// The null forms (~, null and an empty value) and keys that are missing from
+// the document decode to None: yaml.v3 leaves such fields untouched, and the
+// zero value of Option is None. Decode into a fresh value rather than one
+// that already holds Some options if nulls must clear them.
+//
+// Example:
+//
+//	var cfg Config
+//	err := yaml.Unmarshal([]byte("host: ~\nport: 8080\n"), &cfg)
+//	// cfg.Host is None, cfg.Port is Some(8080)
+func (o *Option[T]) UnmarshalYAML(node *yaml.Node) error {
+  if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+    o.Option = option.None[T](nil)
+    return nil
+  }
+
+  var value T
+  if err := node.Decode(&value); err != nil {
+    return fmt.Errorf("optionyaml: line %d, column %d: %w", node.Line, node.Column, err)
+  }
+  o.Option = option.Some(value)
+  return nil
+}
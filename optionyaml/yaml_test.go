@@ -0,0 +1,96 @@
+package optionyaml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+type yamlConfig struct {
+	Host    Option[string]        `yaml:"host"`
+	Port    Option[int]           `yaml:"port,omitempty"`
+	Tags    Option[[]string]      `yaml:"tags,omitempty"`
+	Timeout Option[time.Duration] `yaml:"timeout,omitempty"`
+}
+
+func TestMarshalYAML(t *testing.T) {
+	out, err := yaml.Marshal(yamlConfig{
+		Host: Wrap(option.Some("localhost")),
+		Tags: Wrap(option.Some([]string{"a", "b"})),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "host: localhost\ntags:\n    - a\n    - b\n", string(out))
+
+	out, err = yaml.Marshal(yamlConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "host: null\n", string(out))
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	var cfg yamlConfig
+	err := yaml.Unmarshal([]byte("host: example.com\nport: 8080\ntags: [x]\ntimeout: 1.5s\n"), &cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "example.com", cfg.Host.Unwrap())
+	assert.Equal(t, 8080, cfg.Port.Unwrap())
+	assert.Equal(t, []string{"x"}, cfg.Tags.Unwrap())
+	assert.Equal(t, 1500*time.Millisecond, cfg.Timeout.Unwrap())
+}
+
+func TestUnmarshalYAML_Null(t *testing.T) {
+	for _, doc := range []string{"host: ~\n", "host: null\n", "host:\n", "port: 1\n"} {
+		t.Run(doc, func(t *testing.T) {
+			var cfg yamlConfig
+			require.NoError(t, yaml.Unmarshal([]byte(doc), &cfg))
+			assert.True(t, cfg.Host.IsNone())
+		})
+	}
+}
+
+func TestUnmarshalYAML_Alias(t *testing.T) {
+	var cfg struct {
+		Base Option[int] `yaml:"base"`
+		Port Option[int] `yaml:"port"`
+		None Option[int] `yaml:"none"`
+		Copy Option[int] `yaml:"copy"`
+	}
+	doc := "base: &p 8080\nport: *p\nnone: &n ~\ncopy: *n\n"
+	require.NoError(t, yaml.Unmarshal([]byte(doc), &cfg))
+	assert.Equal(t, 8080, cfg.Port.Unwrap())
+	assert.True(t, cfg.Copy.IsNone())
+}
+
+func TestUnmarshalYAML_Error(t *testing.T) {
+	var cfg yamlConfig
+	err := yaml.Unmarshal([]byte("host: ok\nport:   eighty\n"), &cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "line 2, column 9")
+
+	var typeErr *yaml.TypeError
+	assert.ErrorAs(t, err, &typeErr)
+}
+
+func TestYAML_RoundTrip(t *testing.T) {
+	in := yamlConfig{Host: Wrap(option.Some("h")), Port: Wrap(option.Some(1)), Timeout: Wrap(option.Some(time.Second))}
+	out, err := yaml.Marshal(in)
+	require.NoError(t, err)
+
+	var back yamlConfig
+	require.NoError(t, yaml.Unmarshal(out, &back))
+	assert.True(t, in.Host.Equal(back.Host.Option))
+	assert.True(t, in.Port.Equal(back.Port.Option))
+	assert.True(t, in.Timeout.Equal(back.Timeout.Option))
+	assert.True(t, back.Tags.IsNone())
+}
+
+func TestUnmarshalYAML_Node(t *testing.T) {
+	o := Wrap(option.Some(1))
+	require.NoError(t, o.UnmarshalYAML(&yaml.Node{Kind: yaml.ScalarNode, Value: "~"}))
+	assert.True(t, o.IsNone())
+
+	require.NoError(t, o.UnmarshalYAML(&yaml.Node{Kind: yaml.ScalarNode, Value: "5"}))
+	assert.Equal(t, 5, o.Unwrap())
+}
@@ -0,0 +1,66 @@
+package option
+
+import (
+  "fmt"
+  "net/url"
+  "reflect"
+  "sync"
+  "time"
+)
+
+var (
+  durationType = reflect.TypeFor[time.Duration]()
+  urlType      = reflect.TypeFor[url.URL]()
+
+  // parsers maps a reflect.Type to a func(string, reflect.Value) error
+  // registered with RegisterParser.
+  parsers sync.Map
+)
+
+// Parse converts s into a T and returns Some with the result, or None with an
+// error describing why s is not a valid T. It is the conversion engine shared
+// by UnmarshalText, Flag, Env and the other text-based adapters of the module.
+//
+// Parsers added with RegisterParser take precedence. Otherwise, types
+// implementing encoding.TextUnmarshaler use it, which covers time.Time in RFC
+// 3339 format and the net/netip address types. time.Duration is parsed with
+// time.ParseDuration, url.URL with url.Parse, and strings, booleans and numeric
+// kinds with strconv. Pointers to any of these are allocated and filled in.
+//
+// Example:
+//
+//	option.Parse[int]("42")                    // Some(42)
+//	option.Parse[time.Duration]("1.5s")        // Some(1.5s)
+//	option.Parse[netip.Addr]("10.0.0.1")       // Some(10.0.0.1)
+//	option.Parse[int]("forty-two").UnwrapOr(0) // 0
+func Parse[T any](s string) Option[T] {
+  var value T
+  if err := unmarshalText([]byte(s), reflect.ValueOf(&value).Elem()); err != nil {
+    return None[T](fmt.Errorf("option: parse %q as %s: %w", s, reflect.TypeFor[T](), err))
+  }
+  return Some(value)
+}
+
+// RegisterParser registers parse as the conversion from text to T, replacing
+// the built-in rules and any UnmarshalText method of T. It affects Parse and
+// every adapter built on it, including UnmarshalText, Flag and Env.
+//
+// RegisterParser should be called during program initialization. Registering
+// a parser for the same type twice replaces the previous one.
+//
+// Example:
+//
+//	option.RegisterParser(func(s string) (Level, error) {
+//		return ParseLevel(strings.ToLower(s))
+//	})
+//	lvl := option.Env[Level]("LOG_LEVEL")
+func RegisterParser[T any](parse func(string) (T, error)) {
+  parsers.Store(reflect.TypeFor[T](), func(s string, v reflect.Value) error {
+    value, err := parse(s)
+    if err != nil {
+      return err
+    }
+    v.Set(reflect.ValueOf(&value).Elem())
+    return nil
+  })
+}
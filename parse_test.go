@@ -0,0 +1,93 @@
+package option
+
+import (
+	"errors"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	ts := time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)
+	u, err := url.Parse("https://example.com/a?b=c")
+	require.NoError(t, err)
+	n := 7
+
+	assert.Equal(t, Some("gopher"), Parse[string]("gopher"))
+	assert.Equal(t, Some(""), Parse[string](""))
+	assert.Equal(t, Some(true), Parse[bool]("true"))
+	assert.Equal(t, Some(-42), Parse[int]("-42"))
+	assert.Equal(t, Some[int8](-8), Parse[int8]("-8"))
+	assert.Equal(t, Some[uint16](65535), Parse[uint16]("65535"))
+	assert.Equal(t, Some(0.5), Parse[float64]("0.5"))
+	assert.Equal(t, Some[float32](1.5), Parse[float32]("1.5"))
+	assert.Equal(t, Some(complex(1, 2)), Parse[complex128]("1+2i"))
+	assert.Equal(t, Some(ts), Parse[time.Time]("2024-05-01T12:30:00Z"))
+	assert.Equal(t, Some(1500*time.Millisecond), Parse[time.Duration]("1.5s"))
+	assert.Equal(t, Some(netip.MustParseAddr("::1")), Parse[netip.Addr]("::1"))
+	assert.Equal(t, Some(netip.MustParsePrefix("10.0.0.0/8")), Parse[netip.Prefix]("10.0.0.0/8"))
+	assert.Equal(t, Some(netip.MustParseAddrPort("127.0.0.1:80")), Parse[netip.AddrPort]("127.0.0.1:80"))
+	assert.Equal(t, Some(*u), Parse[url.URL]("https://example.com/a?b=c"))
+	assert.Equal(t, Some(u), Parse[*url.URL]("https://example.com/a?b=c"))
+	assert.Equal(t, Some(&n), Parse[*int]("7"))
+	assert.Equal(t, Some[level](3), Parse[level]("3"))
+}
+
+func TestParse_Errors(t *testing.T) {
+	o := Parse[int]("forty-two")
+	assert.True(t, o.IsNone())
+	assert.ErrorIs(t, o.Error(), strconv.ErrSyntax)
+	assert.EqualError(t, o.Error(), `option: parse "forty-two" as int: strconv.ParseInt: parsing "forty-two": invalid syntax`)
+
+	assert.ErrorIs(t, Parse[int8]("300").Error(), strconv.ErrRange)
+	assert.True(t, Parse[time.Duration]("soon").IsNone())
+	assert.True(t, Parse[url.URL]("http://[::1").IsNone())
+	assert.True(t, Parse[netip.Addr]("localhost").IsNone())
+	assert.ErrorIs(t, Parse[chan int]("x").Error(), ErrUnsupportedType)
+	assert.ErrorIs(t, Parse[[]int]("1,2").Error(), ErrUnsupportedType)
+}
+
+type celsius float64
+
+func TestRegisterParser(t *testing.T) {
+	errNoUnit := errors.New("missing unit")
+	RegisterParser(func(s string) (celsius, error) {
+		v, ok := strings.CutSuffix(s, "C")
+		if !ok {
+			return 0, errNoUnit
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		return celsius(f), err
+	})
+	t.Cleanup(func() { parsers.Delete(reflect.TypeFor[celsius]()) })
+
+	assert.Equal(t, Some[celsius](21.5), Parse[celsius]("21.5C"))
+	assert.ErrorIs(t, Parse[celsius]("21.5").Error(), errNoUnit)
+
+	var o Option[celsius]
+	require.NoError(t, o.UnmarshalText([]byte("-4C")))
+	assert.Equal(t, celsius(-4), o.Unwrap())
+
+	t.Setenv("OPTION_TEST_TEMP", "30C")
+	assert.Equal(t, Some[celsius](30), Env[celsius]("OPTION_TEST_TEMP"))
+}
+
+func TestRegisterParser_OverridesBuiltin(t *testing.T) {
+	RegisterParser(func(s string) (level, error) {
+		if s == "debug" {
+			return 7, nil
+		}
+		return 0, errors.New("unknown level")
+	})
+	t.Cleanup(func() { parsers.Delete(reflect.TypeFor[level]()) })
+
+	assert.Equal(t, Some[level](7), Parse[level]("debug"))
+	assert.True(t, Parse[level]("3").IsNone())
+}
@@ -0,0 +1,81 @@
+package option
+
+import (
+  "fmt"
+  "reflect"
+)
+
+// Optional is a type-erased view of an Option, implemented by every Option[T].
+// It lets reflection-based code such as encoders, validators and config
+// loaders inspect options whose type parameter is only known at run time.
+//
+// Example:
+//
+//	if opt, ok := field.Interface().(option.Optional); ok && opt.IsSome() {
+//		v, _ := opt.Interface()
+//		fmt.Println(v)
+//	}
+type Optional interface {
+  IsSome() bool
+  IsNone() bool
+  Error() error
+
+  // Interface returns the contained value and true for Some, or nil and
+  // false for None.
+  Interface() (any, bool)
+
+  // ElemType returns the type of the contained value, T.
+  ElemType() reflect.Type
+}
+
+// OptionalSetter is a type-erased view of a *Option[T] that can also change
+// the option. Obtain one from an addressable field with
+// field.Addr().Interface().(option.OptionalSetter).
+type OptionalSetter interface {
+  Optional
+
+  // SetSome makes the option Some with v, which must be assignable to T.
+  SetSome(v any) error
+
+  // SetNone makes the option None with the provided error.
+  SetNone(err error)
+}
+
+var (
+  _ Optional       = Option[int]{}
+  _ OptionalSetter = (*Option[int])(nil)
+)
+
+// Interface implements Optional.
+func (o Option[T]) Interface() (any, bool) {
+  if !o.ok {
+    return nil, false
+  }
+  return o.some, true
+}
+
+// ElemType implements Optional.
+func (o Option[T]) ElemType() reflect.Type {
+  return reflect.TypeFor[T]()
+}
+
+// SetSome implements OptionalSetter. It returns an error and leaves the option
+// unchanged if v is not assignable to T. As with Some, a nil v makes the
+// option None with ErrNilValue.
+func (o *Option[T]) SetSome(v any) error {
+  if v == nil {
+    *o = None[T](ErrNilValue)
+    return nil
+  }
+  value, ok := v.(T)
+  if !ok {
+    return fmt.Errorf("option: cannot use %T as %s", v, reflect.TypeFor[T]())
+  }
+  *o = Some(value)
+  return nil
+}
+
+// SetNone implements OptionalSetter.
+func (o *Option[T]) SetNone(err error) {
+  *o = None[T](err)
+}
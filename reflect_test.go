@@ -0,0 +1,66 @@
+package option
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOption_Interface(t *testing.T) {
+	v, ok := Some(42).Interface()
+	assert.True(t, ok)
+	assert.Equal(t, 42, v)
+
+	v, ok = None[int](nil).Interface()
+	assert.False(t, ok)
+	assert.Nil(t, v)
+}
+
+func TestOption_ElemType(t *testing.T) {
+	assert.Equal(t, reflect.TypeFor[int](), None[int](nil).ElemType())
+	assert.Equal(t, reflect.TypeFor[error](), Some[error](errors.New("x")).ElemType())
+}
+
+func TestOption_SetSome(t *testing.T) {
+	var o Option[int]
+	require.NoError(t, o.SetSome(42))
+	assert.Equal(t, 42, o.Unwrap())
+
+	assert.Error(t, o.SetSome("42"))
+	assert.Equal(t, 42, o.Unwrap())
+
+	var p Option[*testStruct]
+	require.NoError(t, p.SetSome(nil))
+	assert.ErrorIs(t, p.Error(), ErrNilValue)
+}
+
+func TestOption_SetNone(t *testing.T) {
+	o := Some(1)
+	expectedErr := errors.New("gone")
+	o.SetNone(expectedErr)
+	assert.True(t, o.IsNone())
+	assert.ErrorIs(t, o.Error(), expectedErr)
+}
+
+func TestOptional_Reflection(t *testing.T) {
+	type record struct {
+		Name Option[string]
+		Age  int
+	}
+	optionalType := reflect.TypeFor[OptionalSetter]()
+
+	var r record
+	v := reflect.ValueOf(&r).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		if !field.Addr().Type().Implements(optionalType) {
+			continue
+		}
+		setter := field.Addr().Interface().(OptionalSetter)
+		require.NoError(t, setter.SetSome("gopher"))
+	}
+	assert.Equal(t, "gopher", r.Name.Unwrap())
+}
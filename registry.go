@@ -0,0 +1,63 @@
+package option
+
+import (
+  "sync"
+)
+
+// errorRegistry maps error messages to the errors registered for them.
+var errorRegistry = struct {
+  sync.RWMutex
+  errs map[string]error
+}{
+  errs: map[string]error{
+    ErrNilValue.Error():    ErrNilValue,
+    ErrKeyNotFound.Error(): ErrKeyNotFound,
+    ErrAbsent.Error():      ErrAbsent,
+    ErrNull.Error():        ErrNull,
+  },
+}
+
+// RegisterError registers err so that decoding a None option whose serialized
+// error message equals err.Error() restores err itself rather than an opaque
+// error. This keeps errors.Is working across process boundaries for sentinel
+// errors. ErrNilValue, ErrKeyNotFound, ErrAbsent and ErrNull are registered
+// by default; when two registered errors share a message, the last
+// registration wins.
+//
+// RegisterError is typically called from an init function:
+//
+//	var ErrUserNotFound = errors.New("user not found")
+//
+//	func init() {
+//		option.RegisterError(ErrUserNotFound)
+//	}
+func RegisterError(err error) {
+  if err == nil {
+    panic("option: RegisterError called with nil error")
+  }
+  errorRegistry.Lock()
+  defer errorRegistry.Unlock()
+  errorRegistry.errs[err.Error()] = err
+}
+
+// decodedError is the opaque error restored from a serialized None option
+// whose message matches no registered error. Only the message survives the
+// round trip.
+type decodedError struct {
+  msg string
+}
+
+func (e *decodedError) Error() string {
+  return e.msg
+}
+
+// decodeError returns the registered error for msg, or an opaque error with
+// that message.
+func decodeError(msg string) error {
+  errorRegistry.RLock()
+  defer errorRegistry.RUnlock()
+  if err, ok := errorRegistry.errs[msg]; ok {
+    return err
+  }
+  return &decodedError{msg: msg}
+}
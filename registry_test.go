@@ -0,0 +1,39 @@
+package option
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterError(t *testing.T) {
+	sentinel := errors.New("registry test: sentinel")
+	assert.NotErrorIs(t, decodeError(sentinel.Error()), sentinel)
+
+	RegisterError(sentinel)
+	assert.ErrorIs(t, decodeError(sentinel.Error()), sentinel)
+}
+
+func TestRegisterError_Defaults(t *testing.T) {
+	assert.ErrorIs(t, decodeError(ErrNilValue.Error()), ErrNilValue)
+	assert.ErrorIs(t, decodeError(ErrKeyNotFound.Error()), ErrKeyNotFound)
+}
+
+func TestRegisterError_Nil(t *testing.T) {
+	assert.Panics(t, func() { RegisterError(nil) })
+}
+
+func TestRegisterError_Binary(t *testing.T) {
+	data, err := None[int](ErrKeyNotFound).MarshalBinary()
+	assert.NoError(t, err)
+
+	var o Option[int]
+	assert.NoError(t, o.UnmarshalBinary(data))
+	assert.ErrorIs(t, o.Error(), ErrKeyNotFound)
+}
+
+func TestDecodeError_Opaque(t *testing.T) {
+	err := decodeError("registry test: unknown")
+	assert.EqualError(t, err, "registry test: unknown")
+}
@@ -0,0 +1,382 @@
+// Package schema generates JSON Schema (draft 2020-12) documents from Go
+// types, with first-class support for option.Option and option.Nullable.
+//
+// Option and Nullable fields are described by the schema of the contained
+// type, made nullable, and are left out of the required list unless tagged
+// `option:"required"`, in which case they are required and not nullable.
+// Other fields are required unless their json tag has the omitempty or
+// omitzero option; pointer fields are nullable.
+//
+// Struct tags add documentation: `description:"..."` sets the description of a
+// property, and `default:"..."` on an Option field sets its default, parsed
+// the same way as option.ApplyDefaults does so the schema matches the filled
+// in value.
+//
+//	type CreateUser struct {
+//		Name  option.Option[string] `json:"name" option:"required" description:"Display name"`
+//		Limit option.Option[int]    `json:"limit" default:"50"`
+//	}
+//
+//	s, err := schema.For[CreateUser]()
+//	data, err := json.MarshalIndent(s, "", "  ")
+//
+// Named struct types other than the root are placed in $defs and referenced
+// with $ref, which also makes recursive types possible.
+package schema
+
+import (
+  "encoding"
+  "encoding/json"
+  "errors"
+  "fmt"
+  "reflect"
+  "regexp"
+  "strings"
+  "time"
+
+  "github.com/kalpio/option"
+)
+
+// Draft is the meta-schema URI of the JSON Schema version produced by For.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+var (
+  ErrUnsupportedType = errors.New("schema: unsupported type")
+)
+
+// Schema is a JSON Schema. Only the keywords the generator produces are
+// modelled.
+type Schema struct {
+  Schema               string             `json:"$schema,omitempty"`
+  Ref                  string             `json:"$ref,omitempty"`
+  Type                 any                `json:"type,omitempty"`
+  Format               string             `json:"format,omitempty"`
+  ContentEncoding      string             `json:"contentEncoding,omitempty"`
+  Description          string             `json:"description,omitempty"`
+  Default              any                `json:"default,omitempty"`
+  Minimum              *float64           `json:"minimum,omitempty"`
+  Items                *Schema            `json:"items,omitempty"`
+  Properties           map[string]*Schema `json:"properties,omitempty"`
+  Required             []string           `json:"required,omitempty"`
+  AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+  AnyOf                []*Schema          `json:"anyOf,omitempty"`
+  Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+// For returns the schema of T.
+func For[T any]() (*Schema, error) {
+  return ForType(reflect.TypeFor[T]())
+}
+
+// ForType returns the schema of t.
+func ForType(t reflect.Type) (*Schema, error) {
+  g := &generator{names: map[reflect.Type]string{}, defs: map[string]*Schema{}}
+  for t.Kind() == reflect.Pointer {
+    t = t.Elem()
+  }
+  if t.Kind() == reflect.Struct && !leaf(t) {
+    g.names[t] = "#"
+  }
+  s, err := g.schema(t)
+  if err != nil {
+    return nil, err
+  }
+  if s.Ref == "#" {
+    s, err = g.object(t)
+    if err != nil {
+      return nil, err
+    }
+  }
+  s.Schema = Draft
+  if len(g.defs) > 0 {
+    s.Defs = g.defs
+  }
+  return s, nil
+}
+
+var (
+  optionalType        = reflect.TypeFor[option.Optional]()
+  jsonMarshalerType   = reflect.TypeFor[json.Marshaler]()
+  textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+  textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+  timeType            = reflect.TypeFor[time.Time]()
+  bytesType           = reflect.TypeFor[[]byte]()
+)
+
+// generator builds the schema of one root type.
+type generator struct {
+  // names maps the struct types seen so far to their $defs names.
+  names map[reflect.Type]string
+  defs  map[string]*Schema
+}
+
+// schema returns the schema of values of type t.
+func (g *generator) schema(t reflect.Type) (*Schema, error) {
+  if elem, ok := optionalElem(t); ok {
+    s, err := g.schema(elem)
+    if err != nil {
+      return nil, err
+    }
+    return nullable(s), nil
+  }
+
+  switch t {
+  case timeType:
+    return &Schema{Type: "string", Format: "date-time"}, nil
+  case bytesType:
+    return &Schema{Type: "string", ContentEncoding: "base64"}, nil
+  }
+  if t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType) {
+    if t.Implements(textMarshalerType) {
+      return &Schema{Type: "string"}, nil
+    }
+    return &Schema{}, nil
+  }
+  if t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType) {
+    return &Schema{Type: "string"}, nil
+  }
+
+  switch t.Kind() {
+  case reflect.Bool:
+    return &Schema{Type: "boolean"}, nil
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    return &Schema{Type: "integer"}, nil
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    zero := 0.0
+    return &Schema{Type: "integer", Minimum: &zero}, nil
+  case reflect.Float32, reflect.Float64:
+    return &Schema{Type: "number"}, nil
+  case reflect.String:
+    return &Schema{Type: "string"}, nil
+  case reflect.Interface:
+    return &Schema{}, nil
+  case reflect.Pointer:
+    s, err := g.schema(t.Elem())
+    if err != nil {
+      return nil, err
+    }
+    return nullable(s), nil
+  case reflect.Slice, reflect.Array:
+    items, err := g.schema(t.Elem())
+    if err != nil {
+      return nil, err
+    }
+    return &Schema{Type: "array", Items: items}, nil
+  case reflect.Map:
+    switch k := t.Key(); {
+    case k.Kind() == reflect.String, k.Implements(textMarshalerType),
+      k.Kind() >= reflect.Int && k.Kind() <= reflect.Uintptr:
+    default:
+      return nil, fmt.Errorf("%w: map key %s", ErrUnsupportedType, k)
+    }
+    values, err := g.schema(t.Elem())
+    if err != nil {
+      return nil, err
+    }
+    return &Schema{Type: "object", AdditionalProperties: values}, nil
+  case reflect.Struct:
+    if t.Name() == "" {
+      return g.object(t)
+    }
+    return g.ref(t)
+  }
+  return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+}
+
+// ref returns a reference to the named struct type t, adding its schema to
+// $defs the first time it is seen.
+func (g *generator) ref(t reflect.Type) (*Schema, error) {
+  if name, ok := g.names[t]; ok {
+    return &Schema{Ref: refTo(name)}, nil
+  }
+
+  name := defName(t)
+  for i := 2; g.defs[name] != nil; i++ {
+    name = fmt.Sprintf("%s%d", defName(t), i)
+  }
+  g.names[t] = name
+  g.defs[name] = &Schema{}
+
+  s, err := g.object(t)
+  if err != nil {
+    return nil, err
+  }
+  g.defs[name] = s
+  return &Schema{Ref: refTo(name)}, nil
+}
+
+func refTo(name string) string {
+  if name == "#" {
+    return "#"
+  }
+  return "#/$defs/" + name
+}
+
+// object returns the object schema of the struct type t.
+func (g *generator) object(t reflect.Type) (*Schema, error) {
+  s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+  if err := g.fields(s, t); err != nil {
+    return nil, err
+  }
+  return s, nil
+}
+
+// fields adds the properties of the struct type t to s, including those of
+// embedded structs without a json tag.
+func (g *generator) fields(s *Schema, t reflect.Type) error {
+  for i := 0; i < t.NumField(); i++ {
+    sf := t.Field(i)
+    tag := sf.Tag.Get("json")
+    if tag == "-" {
+      continue
+    }
+    name, opts, _ := strings.Cut(tag, ",")
+    if sf.Anonymous && name == "" && sf.Type.Kind() == reflect.Struct {
+      if err := g.fields(s, sf.Type); err != nil {
+        return err
+      }
+      continue
+    }
+    if !sf.IsExported() {
+      continue
+    }
+    if name == "" {
+      name = sf.Name
+    }
+
+    prop, err := g.property(sf)
+    if err != nil {
+      return fmt.Errorf("%s.%s: %w", t, sf.Name, err)
+    }
+    s.Properties[name] = prop
+    if required(sf, opts) {
+      s.Required = append(s.Required, name)
+    }
+  }
+  return nil
+}
+
+// property returns the schema of the struct field sf.
+func (g *generator) property(sf reflect.StructField) (*Schema, error) {
+  _, isOptional := optionalElem(sf.Type)
+  requiredOption := isOptional && hasTagOption(sf.Tag.Get("option"), "required")
+
+  t := sf.Type
+  if requiredOption {
+    t, _ = optionalElem(t)
+  }
+  s, err := g.schema(t)
+  if err != nil {
+    return nil, err
+  }
+
+  if desc, ok := sf.Tag.Lookup("description"); ok {
+    s.Description = desc
+  }
+  if text, ok := sf.Tag.Lookup("default"); ok {
+    def, err := defaultValue(sf.Type, text)
+    if err != nil {
+      return nil, err
+    }
+    s.Default = def
+  }
+  return s, nil
+}
+
+// required reports whether the struct field sf with the json tag options opts
+// must be present.
+func required(sf reflect.StructField, opts string) bool {
+  if _, ok := optionalElem(sf.Type); ok {
+    return hasTagOption(sf.Tag.Get("option"), "required")
+  }
+  return !hasTagOption(opts, "omitempty") && !hasTagOption(opts, "omitzero")
+}
+
+// defaultValue parses the default tag text of an Option field of type t and
+// returns its JSON value.
+func defaultValue(t reflect.Type, text string) (any, error) {
+  if !t.Implements(optionalType) {
+    return nil, fmt.Errorf("%w: %s is not an Option", option.ErrInvalidDefault, t)
+  }
+  ptr := reflect.New(t)
+  if err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+    return nil, fmt.Errorf("%w: %q: %w", option.ErrInvalidDefault, text, err)
+  }
+  opt := ptr.Elem().Interface().(option.Optional)
+  value, ok := opt.Interface()
+  if !ok {
+    return nil, fmt.Errorf("%w: %q is not a valid %s", option.ErrInvalidDefault, text, opt.ElemType())
+  }
+
+  data, err := json.Marshal(value)
+  if err != nil {
+    return nil, err
+  }
+  var def any
+  if err := json.Unmarshal(data, &def); err != nil {
+    return nil, err
+  }
+  return def, nil
+}
+
+// optionalElem returns the contained type of Option and Nullable types.
+func optionalElem(t reflect.Type) (reflect.Type, bool) {
+  if t.Implements(optionalType) {
+    return reflect.Zero(t).Interface().(option.Optional).ElemType(), true
+  }
+  if m, ok := t.MethodByName("Option"); ok && m.Type.NumIn() == 1 && m.Type.NumOut() == 1 &&
+    m.Type.Out(0).Implements(optionalType) && t.PkgPath() == optionalType.PkgPath() {
+    return optionalElem(m.Type.Out(0))
+  }
+  return nil, false
+}
+
+// leaf reports whether the struct type t is described without properties.
+func leaf(t reflect.Type) bool {
+  _, ok := optionalElem(t)
+  return ok || t == timeType ||
+    reflect.PointerTo(t).Implements(jsonMarshalerType) ||
+    reflect.PointerTo(t).Implements(textMarshalerType) ||
+    reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// nullable returns a schema that also accepts null.
+func nullable(s *Schema) *Schema {
+  switch typ := s.Type.(type) {
+  case string:
+    s.Type = []string{typ, "null"}
+    return s
+  case []string:
+    return s
+  }
+  if s.Ref == "" && s.AnyOf == nil && s.Type == nil {
+    // The empty schema already accepts null.
+    return s
+  }
+  if n := len(s.AnyOf); n > 0 && s.AnyOf[n-1].Type == "null" {
+    return s
+  }
+  return &Schema{AnyOf: []*Schema{s, {Type: "null"}}}
+}
+
+var pkgPath = regexp.MustCompile(`[\w./-]*\.`)
+
+// defName returns the $defs name of the named type t. Package paths are
+// stripped from instantiated generic types, so Page[pkg.User] becomes
+// Page_User.
+func defName(t reflect.Type) string {
+  name := pkgPath.ReplaceAllString(t.Name(), "")
+  return strings.NewReplacer("[", "_", "]", "", ",", "_", "*", "", " ", "").Replace(name)
+}
+
+// hasTagOption reports whether the comma-separated tag contains opt.
+func hasTagOption(tag, opt string) bool {
+  for tag != "" {
+    var name string
+    name, tag, _ = strings.Cut(tag, ",")
+    if strings.TrimSpace(name) == opt {
+      return true
+    }
+  }
+  return false
+}
@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"encoding/json"
+	"flag"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+type address struct {
+	Street string                `json:"street"`
+	City   option.Option[string] `json:"city" description:"City name"`
+}
+
+type page[T any] struct {
+	Items []T                `json:"items"`
+	Next  option.Option[int] `json:"next,omitempty"`
+}
+
+type timestamps struct {
+	Created time.Time                `json:"created"`
+	Deleted option.Option[time.Time] `json:"deleted"`
+}
+
+type user struct {
+	timestamps
+	ID       uint64                       `json:"id" description:"Unique identifier"`
+	Name     option.Option[string]        `json:"name" option:"required" description:"Display name"`
+	Email    option.Option[string]        `json:"email"`
+	Age      option.Option[int]           `json:"age" default:"18"`
+	Score    float64                      `json:"score,omitempty"`
+	Active   bool                         `json:"active"`
+	Nickname option.Nullable[string]      `json:"nickname"`
+	Home     option.Option[address]       `json:"home"`
+	Work     *address                     `json:"work"`
+	Tags     []string                     `json:"tags,omitempty"`
+	Labels   map[string]string            `json:"labels,omitempty"`
+	Avatar   []byte                       `json:"avatar,omitempty"`
+	Bind     option.Option[netip.Addr]    `json:"bind" default:"127.0.0.1"`
+	Timeout  option.Option[time.Duration] `json:"timeout" default:"30s"`
+	Extra    any                          `json:"extra,omitempty"`
+	Secret   string                       `json:"-"`
+	internal int
+}
+
+type node struct {
+	Value    option.Option[string] `json:"value" default:""`
+	Children []node                `json:"children"`
+	Parent   option.Option[*node]  `json:"parent"`
+}
+
+func assertGolden(t *testing.T, name string, s *Schema) {
+	t.Helper()
+	got, err := json.MarshalIndent(s, "", "  ")
+	require.NoError(t, err)
+	got = append(got, '\n')
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		require.NoError(t, os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), string(got))
+}
+
+func TestFor_Golden(t *testing.T) {
+	tests := []struct {
+		name string
+		gen  func() (*Schema, error)
+	}{
+		{"user", For[user]},
+		{"page", For[page[user]]},
+		{"node", For[node]},
+		{"option", For[option.Option[[]int]]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := tt.gen()
+			require.NoError(t, err)
+			assertGolden(t, tt.name, s)
+		})
+	}
+}
+
+func TestFor_Option(t *testing.T) {
+	s, err := For[user]()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"created", "id", "name", "active", "work"}, s.Required)
+	assert.Equal(t, []string{"string", "null"}, s.Properties["email"].Type)
+	assert.Equal(t, "string", s.Properties["name"].Type)
+	assert.Equal(t, 18.0, s.Properties["age"].Default)
+	assert.Equal(t, "127.0.0.1", s.Properties["bind"].Default)
+	assert.Equal(t, []*Schema{{Ref: "#/$defs/address"}, {Type: "null"}}, s.Properties["home"].AnyOf)
+}
+
+func TestFor_Errors(t *testing.T) {
+	_, err := For[struct {
+		C chan int
+	}]()
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = For[struct {
+		M map[[2]int]string
+	}]()
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+
+	_, err = For[struct {
+		Port option.Option[int] `default:"http"`
+	}]()
+	assert.ErrorIs(t, err, option.ErrInvalidDefault)
+
+	_, err = For[struct {
+		Port int `default:"80"`
+	}]()
+	assert.ErrorIs(t, err, option.ErrInvalidDefault)
+}
@@ -0,0 +1,60 @@
+package option
+
+import (
+  "log/slog"
+  "time"
+)
+
+// LogValue implements slog.LogValuer. A Some option logs as its contained
+// value, with nested LogValuers resolved. A None option logs as a group with
+// present=false and, when the option carries one, the error message.
+//
+// Example:
+//
+//	slog.Info("lookup", "user", option.Some("gopher"))
+//	// level=INFO msg=lookup user=gopher
+//
+//	slog.Info("lookup", "user", option.None[string](errors.New("not found")))
+//	// level=INFO msg=lookup user.present=false user.error="not found"
+func (o Option[T]) LogValue() slog.Value {
+  if !o.ok {
+    if o.err == nil {
+      return slog.GroupValue(slog.Bool("present", false))
+    }
+    return slog.GroupValue(slog.Bool("present", false), slog.String("error", o.err.Error()))
+  }
+  return slog.AnyValue(o.some).Resolve()
+}
+
+// LogAttr returns a slog.Attr for the option, equivalent to slog.Any(key, o).
+// Unlike slog.Any it does not box the option, so Some options of basic kinds
+// (strings, numbers, booleans, time.Time and time.Duration) are logged without
+// allocating.
+//
+// Example:
+//
+//	logger.LogAttrs(ctx, slog.LevelInfo, "request", option.LogAttr("user", user))
+func LogAttr[T any](key string, o Option[T]) slog.Attr {
+  if !o.ok {
+    return slog.Attr{Key: key, Value: o.LogValue()}
+  }
+  switch v := any(o.some).(type) {
+  case string:
+    return slog.String(key, v)
+  case int:
+    return slog.Int(key, v)
+  case int64:
+    return slog.Int64(key, v)
+  case uint64:
+    return slog.Uint64(key, v)
+  case float64:
+    return slog.Float64(key, v)
+  case bool:
+    return slog.Bool(key, v)
+  case time.Time:
+    return slog.Time(key, v)
+  case time.Duration:
+    return slog.Duration(key, v)
+  }
+  return slog.Attr{Key: key, Value: o.LogValue()}
+}
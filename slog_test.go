@@ -0,0 +1,84 @@
+package option
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type secret string
+
+func (secret) LogValue() slog.Value {
+	return slog.StringValue("REDACTED")
+}
+
+func newTestLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(slog.NewTextHandler(buf, &slog.HandlerOptions{
+		ReplaceAttr: func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && (a.Key == slog.TimeKey || a.Key == slog.LevelKey) {
+				return slog.Attr{}
+			}
+			return a
+		},
+	}))
+}
+
+func TestOption_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	logger.Info("lookup", "user", Some("gopher"))
+	assert.Equal(t, "msg=lookup user=gopher\n", buf.String())
+
+	buf.Reset()
+	logger.Info("lookup", "user", None[string](errors.New("not found")))
+	assert.Equal(t, "msg=lookup user.present=false user.error=\"not found\"\n", buf.String())
+
+	buf.Reset()
+	logger.Info("lookup", "user", None[string](nil))
+	assert.Equal(t, "msg=lookup user.present=false\n", buf.String())
+}
+
+func TestOption_LogValue_Nested(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	logger.Info("login", "password", Some(secret("hunter2")))
+	assert.Equal(t, "msg=login password=REDACTED\n", buf.String())
+
+	assert.Equal(t, slog.KindString, Some(secret("hunter2")).LogValue().Kind())
+}
+
+func TestLogAttr(t *testing.T) {
+	assert.True(t, slog.Int("n", 42).Equal(LogAttr("n", Some(42))))
+	assert.True(t, slog.String("s", "x").Equal(LogAttr("s", Some("x"))))
+	assert.True(t, slog.Duration("d", time.Second).Equal(LogAttr("d", Some(time.Second))))
+	assert.True(t, slog.String("p", "REDACTED").Equal(LogAttr("p", Some(secret("x")))))
+
+	attr := LogAttr("n", None[int](errors.New("missing")))
+	assert.Equal(t, "n", attr.Key)
+	assert.Equal(t, slog.KindGroup, attr.Value.Kind())
+	assert.Equal(t, "[present=false error=missing]", attr.Value.String())
+}
+
+func TestLogAttr_NoAllocs(t *testing.T) {
+	some := Some(123456)
+	str := Some("gopher")
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = LogAttr("n", some)
+		_ = LogAttr("s", str)
+	})
+	assert.Zero(t, allocs)
+}
+
+func BenchmarkLogAttr(b *testing.B) {
+	some := Some(123456)
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = LogAttr("n", some)
+	}
+}
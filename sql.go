@@ -0,0 +1,42 @@
+package option
+
+import (
+  "database/sql"
+  "database/sql/driver"
+)
+
+// Scan implements sql.Scanner. SQL NULL makes o None without an error, like
+// JSON null does; any other value is converted to T with the same rules as
+// database/sql uses for Scan targets. On error the option is left unchanged.
+//
+// Example:
+//
+//	var email option.Option[string]
+//	err := db.QueryRow("SELECT email FROM users WHERE id = ?", id).Scan(&email)
+func (o *Option[T]) Scan(src any) error {
+  var v sql.Null[T]
+  if err := v.Scan(src); err != nil {
+    return err
+  }
+  if !v.Valid {
+    *o = None[T](nil)
+    return nil
+  }
+  *o = Some(v.V)
+  return nil
+}
+
+// Value implements driver.Valuer. A None option is stored as SQL NULL and a
+// Some option is converted with driver.DefaultParameterConverter, which honours
+// driver.Valuer implementations of T.
+//
+// Example:
+//
+//	_, err := db.Exec("UPDATE users SET email = ? WHERE id = ?", option.None[string](nil), id)
+//	// sets email to NULL
+func (o Option[T]) Value() (driver.Value, error) {
+  if !o.ok {
+    return nil, nil
+  }
+  return driver.DefaultParameterConverter.ConvertValue(o.some)
+}
@@ -0,0 +1,57 @@
+package option
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOption_Scan(t *testing.T) {
+	o := Some[int64](1)
+	require.NoError(t, o.Scan(nil))
+	assert.True(t, o.IsNone())
+	assert.NoError(t, o.Error())
+
+	require.NoError(t, o.Scan(int64(7)))
+	assert.Equal(t, Some[int64](7), o)
+
+	var s Option[string]
+	require.NoError(t, s.Scan([]byte("bytes")))
+	assert.Equal(t, "bytes", s.Unwrap())
+
+	var i Option[int]
+	require.NoError(t, i.Scan("12"))
+	assert.Error(t, i.Scan("twelve"))
+	assert.Equal(t, 12, i.Unwrap())
+
+	var ts Option[time.Time]
+	now := time.Now()
+	require.NoError(t, ts.Scan(now))
+	assert.True(t, now.Equal(ts.Unwrap()))
+
+	var _ sql.Scanner = &o
+}
+
+func TestOption_Value(t *testing.T) {
+	v, err := None[int](nil).Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+
+	v, err = Some(7).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(7), v)
+
+	v, err = Some(level(2)).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), v)
+
+	v, err = Some(Value("nested")).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "nested", v)
+
+	var _ driver.Valuer = Option[int]{}
+}
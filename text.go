@@ -0,0 +1,216 @@
+package option
+
+import (
+  "encoding"
+  "errors"
+  "fmt"
+  "net/url"
+  "reflect"
+  "strconv"
+  "time"
+)
+
+var (
+  ErrUnsupportedType = errors.New("option: unsupported type")
+)
+
+// MarshalText implements encoding.TextMarshaler. A None option is encoded as
+// empty text. A Some option is encoded with the MarshalText method of the
+// contained value if it has one, with the String method of time.Duration and
+// url.URL, and with strconv formatting for strings, booleans and numeric kinds
+// otherwise.
+//
+// Empty text decodes back into the contained value, so Some("") and None stay
+// distinct. Use Text to give None a text form of its own.
+//
+// Example:
+//
+//	text, _ := option.Some(42).MarshalText()      // "42"
+//	text, _ = option.None[int](nil).MarshalText() // ""
+func (o Option[T]) MarshalText() ([]byte, error) {
+  if !o.ok {
+    return nil, nil
+  }
+  return marshalText(reflect.ValueOf(&o.some).Elem())
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The text, including empty
+// text, is decoded into the contained value with the same rules as Parse and
+// makes the option Some. On error the option is left unchanged.
+//
+// Example:
+//
+//	var port option.Option[int]
+//	flag.TextVar(&port, "port", option.None[int](nil), "listen port")
+func (o *Option[T]) UnmarshalText(text []byte) error {
+  var value T
+  if err := unmarshalText(text, reflect.ValueOf(&value).Elem()); err != nil {
+    return err
+  }
+  *o = Some(value)
+  return nil
+}
+
+// Text is an Option whose None has a text form of its own, NoneText.
+// MarshalText encodes None as NoneText and UnmarshalText decodes NoneText to
+// None; any other text is handled as by Option. The token belongs to the
+// value, so different flags and fields can use different tokens. The zero
+// Text uses empty text for None.
+//
+// Example:
+//
+//	limit := option.Text[int]{NoneText: "-"}
+//	flag.TextVar(&limit, "limit", limit, "maximum results, or - for no limit")
+//	flag.Parse()
+//
+//	if limit.IsSome() {
+//		query = query.Limit(limit.Unwrap())
+//	}
+type Text[T any] struct {
+  Option[T]
+  NoneText string
+}
+
+var (
+  _ encoding.TextMarshaler   = Text[int]{}
+  _ encoding.TextUnmarshaler = (*Text[int])(nil)
+)
+
+// MarshalText implements encoding.TextMarshaler. A None option is encoded as
+// NoneText.
+func (t Text[T]) MarshalText() ([]byte, error) {
+  if !t.ok {
+    return []byte(t.NoneText), nil
+  }
+  return t.Option.MarshalText()
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. Text equal to NoneText
+// decodes to None; any other text is decoded as by Option.UnmarshalText.
+func (t *Text[T]) UnmarshalText(text []byte) error {
+  if string(text) == t.NoneText {
+    t.Option = None[T](nil)
+    return nil
+  }
+  return t.Option.UnmarshalText(text)
+}
+
+// marshalText encodes v as text, delegating to encoding.TextMarshaler when v
+// implements it.
+func marshalText(v reflect.Value) ([]byte, error) {
+  if m, ok := v.Interface().(encoding.TextMarshaler); ok {
+    return m.MarshalText()
+  }
+  if v.CanAddr() {
+    if m, ok := v.Addr().Interface().(encoding.TextMarshaler); ok {
+      return m.MarshalText()
+    }
+  }
+  switch v.Type() {
+  case durationType:
+    return []byte(time.Duration(v.Int()).String()), nil
+  case urlType:
+    u := v.Interface().(url.URL)
+    return []byte(u.String()), nil
+  }
+
+  switch v.Kind() {
+  case reflect.String:
+    return []byte(v.String()), nil
+  case reflect.Bool:
+    return strconv.AppendBool(nil, v.Bool()), nil
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    return strconv.AppendInt(nil, v.Int(), 10), nil
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    return strconv.AppendUint(nil, v.Uint(), 10), nil
+  case reflect.Float32, reflect.Float64:
+    return strconv.AppendFloat(nil, v.Float(), 'g', -1, v.Type().Bits()), nil
+  case reflect.Complex64, reflect.Complex128:
+    return []byte(strconv.FormatComplex(v.Complex(), 'g', -1, v.Type().Bits())), nil
+  case reflect.Pointer:
+    if !v.IsNil() {
+      return marshalText(v.Elem())
+    }
+  }
+  return nil, fmt.Errorf("%w: cannot marshal %s as text", ErrUnsupportedType, v.Type())
+}
+
+// unmarshalText decodes text into the settable value v. It is the conversion
+// engine behind Parse and every text-based adapter: parsers added with
+// RegisterParser come first, then encoding.TextUnmarshaler, then the built-in
+// rules.
+func unmarshalText(text []byte, v reflect.Value) error {
+  if parse, ok := parsers.Load(v.Type()); ok {
+    return parse.(func(string, reflect.Value) error)(string(text), v)
+  }
+  if u, ok := v.Addr().Interface().(encoding.TextUnmarshaler); ok {
+    return u.UnmarshalText(text)
+  }
+
+  s := string(text)
+  switch v.Type() {
+  case durationType:
+    d, err := time.ParseDuration(s)
+    if err != nil {
+      return err
+    }
+    v.SetInt(int64(d))
+    return nil
+  case urlType:
+    u, err := url.Parse(s)
+    if err != nil {
+      return err
+    }
+    v.Set(reflect.ValueOf(*u))
+    return nil
+  }
+
+  switch v.Kind() {
+  case reflect.String:
+    v.SetString(s)
+    return nil
+  case reflect.Bool:
+    b, err := strconv.ParseBool(s)
+    if err != nil {
+      return err
+    }
+    v.SetBool(b)
+    return nil
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+    if err != nil {
+      return err
+    }
+    v.SetInt(n)
+    return nil
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+    if err != nil {
+      return err
+    }
+    v.SetUint(n)
+    return nil
+  case reflect.Float32, reflect.Float64:
+    f, err := strconv.ParseFloat(s, v.Type().Bits())
+    if err != nil {
+      return err
+    }
+    v.SetFloat(f)
+    return nil
+  case reflect.Complex64, reflect.Complex128:
+    c, err := strconv.ParseComplex(s, v.Type().Bits())
+    if err != nil {
+      return err
+    }
+    v.SetComplex(c)
+    return nil
+  case reflect.Pointer:
+    p := reflect.New(v.Type().Elem())
+    if err := unmarshalText(text, p.Elem()); err != nil {
+      return err
+    }
+    v.Set(p)
+    return nil
+  }
+  return fmt.Errorf("%w: cannot unmarshal text into %s", ErrUnsupportedType, v.Type())
+}
@@ -0,0 +1,178 @@
+package option
+
+import (
+	"encoding/xml"
+	"errors"
+	"flag"
+	"math/big"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type level int
+
+func TestOption_MarshalText(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{ MarshalText() ([]byte, error) }
+		want  string
+	}{
+		{"string", Some("hello"), "hello"},
+		{"int", Some(-42), "-42"},
+		{"uint8", Some(uint8(255)), "255"},
+		{"float", Some(1.5), "1.5"},
+		{"float32", Some(float32(0.1)), "0.1"},
+		{"bool", Some(true), "true"},
+		{"complex", Some(1 + 2i), "(1+2i)"},
+		{"named", Some(level(3)), "3"},
+		{"text marshaler", Some(netip.MustParseAddr("10.0.0.1")), "10.0.0.1"},
+		{"pointer", Some(big.NewInt(7)), "7"},
+		{"duration", Some(90 * time.Second), "1m30s"},
+		{"url", Some(url.URL{Scheme: "https", Host: "example.com"}), "https://example.com"},
+		{"none", None[int](errors.New("missing")), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, err := tt.value.MarshalText()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(text))
+		})
+	}
+}
+
+func TestOption_MarshalText_Unsupported(t *testing.T) {
+	_, err := Some([]int{1}).MarshalText()
+	assert.ErrorIs(t, err, ErrUnsupportedType)
+}
+
+func TestOption_UnmarshalText(t *testing.T) {
+	var i Option[int]
+	require.NoError(t, i.UnmarshalText([]byte("42")))
+	assert.Equal(t, 42, i.Unwrap())
+
+	var f Option[float64]
+	require.NoError(t, f.UnmarshalText([]byte("2.5")))
+	assert.Equal(t, 2.5, f.Unwrap())
+
+	var b Option[bool]
+	require.NoError(t, b.UnmarshalText([]byte("true")))
+	assert.True(t, b.Unwrap())
+
+	var l Option[level]
+	require.NoError(t, l.UnmarshalText([]byte("3")))
+	assert.Equal(t, level(3), l.Unwrap())
+
+	var addr Option[netip.Addr]
+	require.NoError(t, addr.UnmarshalText([]byte("10.0.0.1")))
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), addr.Unwrap())
+
+	var n Option[*big.Int]
+	require.NoError(t, n.UnmarshalText([]byte("12345678901234567890")))
+	assert.Equal(t, "12345678901234567890", n.Unwrap().String())
+
+	var d Option[time.Duration]
+	require.NoError(t, d.UnmarshalText([]byte("1.5s")))
+	assert.Equal(t, 1500*time.Millisecond, d.Unwrap())
+
+	var s Option[string]
+	require.NoError(t, s.UnmarshalText([]byte("")))
+	assert.True(t, s.IsSome())
+	assert.Equal(t, "", s.Unwrap())
+}
+
+func TestOption_UnmarshalText_Error(t *testing.T) {
+	i := Some(1)
+	err := i.UnmarshalText([]byte("abc"))
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+	assert.Equal(t, 1, i.Unwrap())
+
+	var small Option[int8]
+	assert.ErrorIs(t, small.UnmarshalText([]byte("300")), strconv.ErrRange)
+
+	var slice Option[[]int]
+	assert.ErrorIs(t, slice.UnmarshalText([]byte("1")), ErrUnsupportedType)
+}
+
+func TestOption_UnmarshalText_Empty(t *testing.T) {
+	var i Option[int]
+	assert.ErrorIs(t, i.UnmarshalText([]byte("")), strconv.ErrSyntax)
+	assert.True(t, i.IsNone())
+}
+
+func TestText(t *testing.T) {
+	dash := Text[string]{NoneText: "-"}
+	text, err := dash.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "-", string(text))
+
+	require.NoError(t, dash.UnmarshalText([]byte("")))
+	assert.Equal(t, "", dash.Unwrap())
+	require.NoError(t, dash.UnmarshalText([]byte("-")))
+	assert.True(t, dash.IsNone())
+
+	dash.Option = Some("x")
+	text, err = dash.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "x", string(text))
+
+	var empty Text[int]
+	require.NoError(t, empty.UnmarshalText([]byte("")))
+	assert.True(t, empty.IsNone())
+	require.NoError(t, empty.UnmarshalText([]byte("5")))
+	assert.Equal(t, 5, empty.Unwrap())
+	assert.ErrorIs(t, empty.UnmarshalText([]byte("-")), strconv.ErrSyntax)
+	assert.Equal(t, 5, empty.Unwrap())
+}
+
+func TestText_Flag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	limit := Text[int]{NoneText: "-"}
+	fs.TextVar(&limit, "limit", limit, "maximum results")
+
+	require.NoError(t, fs.Parse([]string{"-limit", "10"}))
+	assert.Equal(t, 10, limit.Unwrap())
+	require.NoError(t, fs.Parse([]string{"-limit", "-"}))
+	assert.True(t, limit.IsNone())
+}
+
+func TestOption_Text_Flag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	var port Option[int]
+	fs.TextVar(&port, "port", None[int](nil), "listen port")
+
+	assert.True(t, port.IsNone())
+	require.NoError(t, fs.Parse([]string{"-port", "8080"}))
+	assert.Equal(t, 8080, port.Unwrap())
+}
+
+func TestOption_Text_XMLAttr(t *testing.T) {
+	type item struct {
+		ID Option[int] `xml:"id,attr"`
+	}
+
+	var it item
+	require.NoError(t, xml.Unmarshal([]byte(`<item id="7"></item>`), &it))
+	assert.Equal(t, 7, it.ID.Unwrap())
+}
+
+func BenchmarkOption_MarshalText(b *testing.B) {
+	some := Some(42)
+	for i := 0; i < b.N; i++ {
+		_, _ = some.MarshalText()
+	}
+}
+
+func BenchmarkOption_UnmarshalText(b *testing.B) {
+	var o Option[int]
+	text := []byte("42")
+	for i := 0; i < b.N; i++ {
+		_ = o.UnmarshalText(text)
+	}
+}
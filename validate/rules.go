@@ -0,0 +1,197 @@
+package validate
+
+import (
+  "cmp"
+  "fmt"
+  "reflect"
+  "regexp"
+  "slices"
+  "strconv"
+  "strings"
+  "unicode/utf8"
+)
+
+// rule is a parsed rule of a validate tag. check returns a message describing
+// why v fails the rule, or the empty string if it passes.
+type rule struct {
+  name  string
+  param string
+  check func(v reflect.Value) string
+}
+
+// parseRules parses a validate tag for fields whose values have type t.
+func parseRules(tag string, t reflect.Type) ([]rule, error) {
+  var rules []rule
+  for tag != "" {
+    var item string
+    if strings.HasPrefix(strings.TrimSpace(tag), "regex=") {
+      item, tag = tag, ""
+    } else {
+      item, tag, _ = strings.Cut(tag, ",")
+    }
+    name, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+    if name == "" {
+      continue
+    }
+
+    var (
+      check func(reflect.Value) string
+      err   error
+    )
+    switch name {
+    case "min":
+      check, err = boundRule(t, param, +1)
+    case "max":
+      check, err = boundRule(t, param, -1)
+    case "len":
+      check, err = lenRule(t, param)
+    case "oneof":
+      check, err = oneofRule(t, param)
+    case "regex":
+      check, err = regexRule(t, param)
+    default:
+      err = fmt.Errorf("unknown rule %q", name)
+    }
+    if err != nil {
+      return nil, err
+    }
+    rules = append(rules, rule{name: name, param: param, check: check})
+  }
+  return rules, nil
+}
+
+// boundRule builds min (sign +1) and max (sign -1) rules.
+func boundRule(t reflect.Type, param string, sign int) (func(reflect.Value) string, error) {
+  word := "least"
+  if sign < 0 {
+    word = "most"
+  }
+  fail := func(cmp int) bool { return cmp != 0 && cmp != sign }
+
+  switch t.Kind() {
+  case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+    n, err := strconv.ParseInt(param, 10, t.Bits())
+    if err != nil {
+      return nil, err
+    }
+    return func(v reflect.Value) string {
+      if fail(cmp.Compare(v.Int(), n)) {
+        return "must be at " + word + " " + param
+      }
+      return ""
+    }, nil
+  case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+    n, err := strconv.ParseUint(param, 10, t.Bits())
+    if err != nil {
+      return nil, err
+    }
+    return func(v reflect.Value) string {
+      if fail(cmp.Compare(v.Uint(), n)) {
+        return "must be at " + word + " " + param
+      }
+      return ""
+    }, nil
+  case reflect.Float32, reflect.Float64:
+    f, err := strconv.ParseFloat(param, t.Bits())
+    if err != nil {
+      return nil, err
+    }
+    return func(v reflect.Value) string {
+      if fail(cmp.Compare(v.Float(), f)) {
+        return "must be at " + word + " " + param
+      }
+      return ""
+    }, nil
+  }
+
+  n, unit, err := lengthParam(t, param)
+  if err != nil {
+    return nil, err
+  }
+  return func(v reflect.Value) string {
+    if fail(cmp.Compare(length(v), n)) {
+      return "must have at " + word + " " + param + " " + unit
+    }
+    return ""
+  }, nil
+}
+
+// lenRule builds len rules.
+func lenRule(t reflect.Type, param string) (func(reflect.Value) string, error) {
+  n, unit, err := lengthParam(t, param)
+  if err != nil {
+    return nil, err
+  }
+  return func(v reflect.Value) string {
+    if length(v) != n {
+      return "must have exactly " + param + " " + unit
+    }
+    return ""
+  }, nil
+}
+
+// oneofRule builds oneof rules.
+func oneofRule(t reflect.Type, param string) (func(reflect.Value) string, error) {
+  switch t.Kind() {
+  case reflect.String, reflect.Bool,
+    reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+    reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+    reflect.Float32, reflect.Float64:
+  default:
+    return nil, fmt.Errorf("oneof cannot be applied to %s", t)
+  }
+  words := strings.Fields(param)
+  if len(words) == 0 {
+    return nil, fmt.Errorf("oneof needs at least one value")
+  }
+  return func(v reflect.Value) string {
+    if !slices.Contains(words, fmt.Sprint(v.Interface())) {
+      return "must be one of " + strings.Join(words, ", ")
+    }
+    return ""
+  }, nil
+}
+
+// regexRule builds regex rules.
+func regexRule(t reflect.Type, param string) (func(reflect.Value) string, error) {
+  if t.Kind() != reflect.String {
+    return nil, fmt.Errorf("regex cannot be applied to %s", t)
+  }
+  re, err := regexp.Compile(param)
+  if err != nil {
+    return nil, err
+  }
+  return func(v reflect.Value) string {
+    if !re.MatchString(v.String()) {
+      return "must match " + param
+    }
+    return ""
+  }, nil
+}
+
+// lengthParam parses the parameter of a length rule for values of type t and
+// returns the unit the length is counted in.
+func lengthParam(t reflect.Type, param string) (int, string, error) {
+  var unit string
+  switch t.Kind() {
+  case reflect.String:
+    unit = "characters"
+  case reflect.Slice, reflect.Array, reflect.Map:
+    unit = "elements"
+  default:
+    return 0, "", fmt.Errorf("length cannot be checked on %s", t)
+  }
+  n, err := strconv.Atoi(param)
+  if err != nil {
+    return 0, "", err
+  }
+  return n, unit, nil
+}
+
+// length returns the length of v, counting strings in runes.
+func length(v reflect.Value) int {
+  if v.Kind() == reflect.String {
+    return utf8.RuneCountInString(v.String())
+  }
+  return v.Len()
+}
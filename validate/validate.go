@@ -0,0 +1,260 @@
+// Package validate checks declarative rules on struct fields, most usefully on
+// option.Option fields, where a rule only runs when the field is Some.
+//
+// Rules are given in a `validate` struct tag as a comma-separated list:
+//
+//	type Signup struct {
+//		Name  option.Option[string] `validate:"min=2,max=40"`
+//		Age   option.Option[int]    `validate:"min=13"`
+//		Plan  option.Option[string] `validate:"oneof=free pro team"`
+//		Code  option.Option[string] `validate:"len=6,regex=^[0-9]+$"`
+//		Tags  []string              `validate:"max=5"`
+//		Items []Item
+//	}
+//
+//	if err := validate.Struct(signup); err != nil {
+//		var errs validate.Errors
+//		errors.As(err, &errs) // one *FieldError per failed rule
+//	}
+//
+// The supported rules are:
+//
+//	min=N    numbers must be at least N; strings, slices and maps must have at least N elements
+//	max=N    numbers must be at most N; strings, slices and maps must have at most N elements
+//	len=N    strings, slices and maps must have exactly N elements
+//	oneof=.. the value, formatted with fmt, must be one of the space-separated words
+//	regex=.. strings must match the regular expression; it must be the last rule
+//	         of the tag, as it takes the rest of the tag including commas
+//
+// String lengths are counted in runes. Fields that are None options or nil
+// pointers are skipped. Nested structs, options of structs, pointers to
+// structs and slices or arrays of structs are validated recursively, and
+// errors name the failing field with a path such as "Items[2].SKU".
+//
+// The rules of each struct type are parsed once and cached. A malformed tag is
+// reported as an error wrapping ErrInvalidRule.
+package validate
+
+import (
+  "errors"
+  "fmt"
+  "reflect"
+  "strings"
+  "sync"
+
+  "github.com/kalpio/option"
+)
+
+var (
+  ErrInvalid     = errors.New("validate: invalid value")
+  ErrInvalidRule = errors.New("validate: invalid rule")
+)
+
+// FieldError describes a field that failed a rule. It matches ErrInvalid with
+// errors.Is.
+type FieldError struct {
+  Path  string // path of the field, such as "Address.City" or "Items[0].SKU"
+  Rule  string // name of the failed rule, such as "min"
+  Param string // parameter of the rule, such as "1"
+  Value any    // value of the field, or of the Option's contained value
+
+  msg string
+}
+
+func (e *FieldError) Error() string {
+  return "validate: " + e.Path + ": " + e.msg
+}
+
+func (e *FieldError) Unwrap() error {
+  return ErrInvalid
+}
+
+// Errors lists the fields that failed validation, in field order.
+type Errors []*FieldError
+
+func (e Errors) Error() string {
+  msgs := make([]string, len(e))
+  for i, f := range e {
+    msgs[i] = f.Error()
+  }
+  return strings.Join(msgs, "; ")
+}
+
+func (e Errors) Unwrap() []error {
+  errs := make([]error, len(e))
+  for i, f := range e {
+    errs[i] = f
+  }
+  return errs
+}
+
+// Struct validates the struct v, or the struct v points to, and returns an
+// Errors value listing every failed rule, or nil if all rules pass.
+func Struct(v any) error {
+  rv := reflect.ValueOf(v)
+  for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+    rv = rv.Elem()
+  }
+  if rv.Kind() != reflect.Struct {
+    return fmt.Errorf("validate: Struct called with %T, want a struct", v)
+  }
+
+  var errs Errors
+  if err := validateStruct(rv, "", &errs); err != nil {
+    return err
+  }
+  if len(errs) > 0 {
+    return errs
+  }
+  return nil
+}
+
+// validateStruct checks the fields of the struct v, appending failures to errs.
+func validateStruct(v reflect.Value, prefix string, errs *Errors) error {
+  p, err := planOf(v.Type())
+  if err != nil {
+    return err
+  }
+  for _, f := range p.fields {
+    if err := validateValue(v.Field(f.index), f.rules, prefix+f.name, errs); err != nil {
+      return err
+    }
+  }
+  return nil
+}
+
+// validateValue applies rules to v and descends into it.
+func validateValue(v reflect.Value, rules []rule, path string, errs *Errors) error {
+  if opt, ok := v.Interface().(option.Optional); ok {
+    value, ok := opt.Interface()
+    if !ok {
+      return nil
+    }
+    v = reflect.ValueOf(value)
+  }
+  for v.Kind() == reflect.Pointer {
+    if v.IsNil() {
+      return nil
+    }
+    v = v.Elem()
+  }
+
+  for _, r := range rules {
+    if msg := r.check(v); msg != "" {
+      *errs = append(*errs, &FieldError{Path: path, Rule: r.name, Param: r.param, Value: v.Interface(), msg: msg})
+    }
+  }
+
+  switch v.Kind() {
+  case reflect.Struct:
+    return validateStruct(v, path+".", errs)
+  case reflect.Slice, reflect.Array:
+    if nested, err := dive(v.Type().Elem(), nil); !nested {
+      return err
+    }
+    for i := 0; i < v.Len(); i++ {
+      if err := validateValue(v.Index(i), nil, fmt.Sprintf("%s[%d]", path, i), errs); err != nil {
+        return err
+      }
+    }
+  }
+  return nil
+}
+
+var optionalType = reflect.TypeFor[option.Optional]()
+
+// dive reports whether values of type t may hold structs to validate, and
+// returns the error of building the plans of those structs. visiting holds the
+// struct types whose plans are being built, which may contain themselves.
+func dive(t reflect.Type, visiting map[reflect.Type]bool) (bool, error) {
+  t = valueType(t)
+  switch t.Kind() {
+  case reflect.Struct:
+    if visiting[t] {
+      return true, nil
+    }
+    p, err := buildPlan(t, visiting)
+    if err != nil {
+      return false, err
+    }
+    return len(p.fields) > 0, nil
+  case reflect.Slice, reflect.Array:
+    return dive(t.Elem(), visiting)
+  }
+  return false, nil
+}
+
+// field is one field of a plan.
+type field struct {
+  name  string
+  index int
+  rules []rule
+}
+
+// plan lists the fields of a struct type that need validating.
+type plan struct {
+  fields []field
+}
+
+// planEntry is a cached plan or the error building it.
+type planEntry struct {
+  plan *plan
+  err  error
+}
+
+var planCache sync.Map
+
+// planOf returns the cached validation plan for the struct type t.
+func planOf(t reflect.Type) (*plan, error) {
+  return buildPlan(t, nil)
+}
+
+func buildPlan(t reflect.Type, visiting map[reflect.Type]bool) (*plan, error) {
+  if cached, ok := planCache.Load(t); ok {
+    e := cached.(planEntry)
+    return e.plan, e.err
+  }
+  if visiting == nil {
+    visiting = map[reflect.Type]bool{}
+  }
+  visiting[t] = true
+  defer delete(visiting, t)
+
+  p := &plan{}
+  for i := 0; i < t.NumField(); i++ {
+    sf := t.Field(i)
+    if !sf.IsExported() {
+      continue
+    }
+    rules, err := parseRules(sf.Tag.Get("validate"), valueType(sf.Type))
+    if err != nil {
+      err = fmt.Errorf("%w: %s.%s: %w", ErrInvalidRule, t, sf.Name, err)
+      planCache.Store(t, planEntry{err: err})
+      return nil, err
+    }
+    nested, err := dive(sf.Type, visiting)
+    if err != nil {
+      planCache.Store(t, planEntry{err: err})
+      return nil, err
+    }
+    if len(rules) > 0 || nested {
+      p.fields = append(p.fields, field{name: sf.Name, index: i, rules: rules})
+    }
+  }
+
+  cached, _ := planCache.LoadOrStore(t, planEntry{plan: p})
+  e := cached.(planEntry)
+  return e.plan, e.err
+}
+
+// valueType returns the type that rules on a field of type t are applied to,
+// looking through options and pointers.
+func valueType(t reflect.Type) reflect.Type {
+  if t.Implements(optionalType) {
+    t = reflect.Zero(t).Interface().(option.Optional).ElemType()
+  }
+  for t.Kind() == reflect.Pointer {
+    t = t.Elem()
+  }
+  return t
+}
@@ -0,0 +1,189 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kalpio/option"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type item struct {
+	SKU      option.Option[string] `validate:"regex=^[A-Z]{2}-[0-9]{1,3}$"`
+	Quantity int                   `validate:"min=1,max=99"`
+}
+
+type address struct {
+	City    option.Option[string] `validate:"min=2"`
+	Country string                `validate:"len=2"`
+}
+
+type signup struct {
+	Name     option.Option[string]  `validate:"min=2,max=10"`
+	Age      option.Option[int]     `validate:"min=13,max=130"`
+	Score    option.Option[float64] `validate:"min=0.5"`
+	Plan     option.Option[string]  `validate:"oneof=free pro team"`
+	Level    option.Option[uint8]   `validate:"oneof=1 2 3"`
+	Tags     []string               `validate:"max=2"`
+	Address  option.Option[address]
+	Billing  *address
+	Items    []item            `validate:"min=1"`
+	Labels   map[string]string `validate:"max=1"`
+	internal string            `validate:"min=100"`
+	Untagged string
+}
+
+func valid() signup {
+	return signup{
+		Name:    option.Some("gopher"),
+		Age:     option.Some(14),
+		Plan:    option.Some("pro"),
+		Address: option.Some(address{City: option.Some("Lodz"), Country: "PL"}),
+		Items:   []item{{SKU: option.Some("AB-1"), Quantity: 1}},
+	}
+}
+
+func TestStruct_Valid(t *testing.T) {
+	s := valid()
+	assert.NoError(t, Struct(s))
+	assert.NoError(t, Struct(&s))
+}
+
+func TestStruct_NoneSkipped(t *testing.T) {
+	assert.NoError(t, Struct(signup{Items: []item{{Quantity: 5}}}))
+}
+
+func TestStruct_Errors(t *testing.T) {
+	s := signup{
+		Name:    option.Some("g"),
+		Age:     option.Some(200),
+		Score:   option.Some(0.1),
+		Plan:    option.Some("enterprise"),
+		Level:   option.Some[uint8](4),
+		Tags:    []string{"a", "b", "c"},
+		Address: option.Some(address{City: option.Some("Ł"), Country: "POL"}),
+		Billing: &address{Country: "D"},
+		Items:   []item{{SKU: option.Some("AB-1"), Quantity: 1}, {SKU: option.Some("ab-1"), Quantity: 100}},
+		Labels:  map[string]string{"a": "1", "b": "2"},
+	}
+
+	err := Struct(s)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrInvalid)
+
+	var errs Errors
+	require.True(t, errors.As(err, &errs))
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Error())
+	}
+	assert.Equal(t, []string{
+		"validate: Name: must have at least 2 characters",
+		"validate: Age: must be at most 130",
+		"validate: Score: must be at least 0.5",
+		"validate: Plan: must be one of free, pro, team",
+		"validate: Level: must be one of 1, 2, 3",
+		"validate: Tags: must have at most 2 elements",
+		"validate: Address.City: must have at least 2 characters",
+		"validate: Address.Country: must have exactly 2 characters",
+		"validate: Billing.Country: must have exactly 2 characters",
+		"validate: Items[1].SKU: must match ^[A-Z]{2}-[0-9]{1,3}$",
+		"validate: Items[1].Quantity: must be at most 99",
+		"validate: Labels: must have at most 1 elements",
+	}, got)
+
+	assert.Equal(t, &FieldError{Path: "Age", Rule: "max", Param: "130", Value: 200, msg: "must be at most 130"}, errs[1])
+	assert.Equal(t, "Items[1].Quantity", errs[10].Path)
+}
+
+func TestStruct_MissingSliceMinimum(t *testing.T) {
+	s := valid()
+	s.Items = nil
+	var errs Errors
+	require.ErrorAs(t, Struct(s), &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "Items", errs[0].Path)
+	assert.Equal(t, "min", errs[0].Rule)
+}
+
+type node struct {
+	Name     option.Option[string] `validate:"min=1"`
+	Children []node
+	Parent   *node
+}
+
+func TestStruct_Recursive(t *testing.T) {
+	n := node{Name: option.Some("root"), Children: []node{{Name: option.Some("")}, {Children: []node{{Name: option.Some("")}}}}}
+	var errs Errors
+	require.ErrorAs(t, Struct(n), &errs)
+	require.Len(t, errs, 2)
+	assert.Equal(t, "Children[0].Name", errs[0].Path)
+	assert.Equal(t, "Children[1].Children[0].Name", errs[1].Path)
+}
+
+func TestStruct_InvalidRules(t *testing.T) {
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"unknown", struct {
+			A option.Option[int] `validate:"positive"`
+		}{}},
+		{"bad number", struct {
+			A option.Option[int] `validate:"min=one"`
+		}{}},
+		{"overflow", struct {
+			A option.Option[int8] `validate:"max=1000"`
+		}{}},
+		{"length of number", struct {
+			A option.Option[int] `validate:"len=2"`
+		}{}},
+		{"regex on int", struct {
+			A int `validate:"regex=^1$"`
+		}{}},
+		{"bad regex", struct {
+			A string `validate:"regex=("`
+		}{}},
+		{"empty oneof", struct {
+			A string `validate:"oneof="`
+		}{}},
+		{"nested", struct {
+			B []struct {
+				A bool `validate:"min=1"`
+			}
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, Struct(tt.v), ErrInvalidRule)
+		})
+	}
+}
+
+func TestStruct_RegexWithComma(t *testing.T) {
+	type code struct {
+		Code string `validate:"min=2,regex=^[a-z]{2,4}$"`
+	}
+	assert.NoError(t, Struct(code{Code: "abc"}))
+	assert.ErrorIs(t, Struct(code{Code: "abcde"}), ErrInvalid)
+}
+
+func TestStruct_RegexAfterSpace(t *testing.T) {
+	type code struct {
+		Code string `validate:"min=1, regex=^[a-z]{1,3}$"`
+	}
+	assert.NoError(t, Struct(code{Code: "ab"}))
+
+	var errs Errors
+	require.ErrorAs(t, Struct(code{Code: "abcd"}), &errs)
+	require.Len(t, errs, 1)
+	assert.Equal(t, "regex", errs[0].Rule)
+	assert.Equal(t, "^[a-z]{1,3}$", errs[0].Param)
+}
+
+func TestStruct_NotStruct(t *testing.T) {
+	assert.Error(t, Struct(42))
+	assert.Error(t, Struct((*signup)(nil)))
+}
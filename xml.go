@@ -0,0 +1,97 @@
+package option
+
+import (
+  "encoding/xml"
+  "reflect"
+)
+
+// xsiNamespace is the XML Schema instance namespace that defines xsi:nil.
+const xsiNamespace = "http://www.w3.org/2001/XMLSchema-instance"
+
+// MarshalXML implements xml.Marshaler. A Some option is encoded as an element
+// holding its contained value; a None option is omitted.
+//
+// Example:
+//
+//	type Order struct {
+//		ID   int                   `xml:"id"`
+//		Note option.Option[string] `xml:"note"`
+//	}
+//
+//	out, _ := xml.Marshal(Order{ID: 1})
+//	// <Order><id>1</id></Order>
+func (o Option[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+  if !o.ok {
+    return nil
+  }
+  return e.EncodeElement(o.some, start)
+}
+
+// UnmarshalXML implements xml.Unmarshaler. The element is decoded into the
+// contained value, except that an element marked xsi:nil="true" decodes to
+// None. A missing element leaves the field at its zero value, which is None.
+//
+// Example:
+//
+//	var order Order
+//	err := xml.Unmarshal([]byte(`<Order><id>1</id></Order>`), &order)
+//	// order.Note is None
+func (o *Option[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+  if isXSINil(start) {
+    *o = None[T](nil)
+    return d.Skip()
+  }
+
+  var value T
+  if err := d.DecodeElement(&value, &start); err != nil {
+    return err
+  }
+  *o = Some(value)
+  return nil
+}
+
+// MarshalXMLAttr implements xml.MarshalerAttr. A Some option is encoded as an
+// attribute using the same text encoding as MarshalText; a None option is
+// omitted.
+//
+// Example:
+//
+//	type Item struct {
+//		SKU option.Option[string] `xml:"sku,attr"`
+//	}
+func (o Option[T]) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+  if !o.ok {
+    return xml.Attr{}, nil
+  }
+  text, err := o.MarshalText()
+  if err != nil {
+    return xml.Attr{}, err
+  }
+  return xml.Attr{Name: name, Value: string(text)}, nil
+}
+
+// UnmarshalXMLAttr implements xml.UnmarshalerAttr. The attribute value, even
+// an empty one, is decoded into the contained value with the same rules as
+// UnmarshalText. A missing attribute leaves the field at its zero value,
+// which is None.
+func (o *Option[T]) UnmarshalXMLAttr(attr xml.Attr) error {
+  var value T
+  if err := unmarshalText([]byte(attr.Value), reflect.ValueOf(&value).Elem()); err != nil {
+    return err
+  }
+  *o = Some(value)
+  return nil
+}
+
+// isXSINil reports whether start carries an xsi:nil="true" attribute.
+// Documents that use the xsi prefix without declaring the namespace are
+// accepted as well.
+func isXSINil(start xml.StartElement) bool {
+  for _, attr := range start.Attr {
+    if attr.Name.Local != "nil" || (attr.Name.Space != xsiNamespace && attr.Name.Space != "xsi") {
+      continue
+    }
+    return attr.Value == "true" || attr.Value == "1"
+  }
+  return false
+}
@@ -0,0 +1,95 @@
+package option
+
+import (
+	"encoding/xml"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type xmlOrder struct {
+	XMLName xml.Name          `xml:"order"`
+	ID      int               `xml:"id,attr"`
+	SKU     Option[string]    `xml:"sku,attr"`
+	Qty     Option[int]       `xml:"qty,attr"`
+	Note    Option[string]    `xml:"note"`
+	Price   Option[float64]   `xml:"price"`
+	Shipped Option[time.Time] `xml:"shipped"`
+}
+
+func TestOption_MarshalXML(t *testing.T) {
+	out, err := xml.Marshal(xmlOrder{
+		ID:    1,
+		SKU:   Some("A-1"),
+		Note:  Some("fragile"),
+		Price: None[float64](errors.New("unpriced")),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `<order id="1" sku="A-1"><note>fragile</note></order>`, string(out))
+}
+
+func TestOption_UnmarshalXML(t *testing.T) {
+	var order xmlOrder
+	doc := `<order id="1" qty="3"><note>fragile</note><price>9.5</price><shipped>2024-01-02T03:04:05Z</shipped></order>`
+	require.NoError(t, xml.Unmarshal([]byte(doc), &order))
+
+	assert.True(t, order.SKU.IsNone())
+	assert.Equal(t, 3, order.Qty.Unwrap())
+	assert.Equal(t, "fragile", order.Note.Unwrap())
+	assert.Equal(t, 9.5, order.Price.Unwrap())
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), order.Shipped.Unwrap())
+}
+
+func TestOption_UnmarshalXML_Missing(t *testing.T) {
+	var order xmlOrder
+	require.NoError(t, xml.Unmarshal([]byte(`<order id="1"></order>`), &order))
+	assert.True(t, order.SKU.IsNone())
+	assert.True(t, order.Qty.IsNone())
+	assert.True(t, order.Note.IsNone())
+	assert.True(t, order.Price.IsNone())
+}
+
+func TestOption_UnmarshalXML_Nil(t *testing.T) {
+	docs := []string{
+		`<order xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><note xsi:nil="true"/><price>1</price></order>`,
+		`<order><note xsi:nil="true"></note><price>1</price></order>`,
+		`<order xmlns:i="http://www.w3.org/2001/XMLSchema-instance"><note i:nil="1">ignored</note><price>1</price></order>`,
+	}
+	for _, doc := range docs {
+		order := xmlOrder{Note: Some("previous")}
+		require.NoError(t, xml.Unmarshal([]byte(doc), &order))
+		assert.True(t, order.Note.IsNone(), doc)
+		assert.Equal(t, 1.0, order.Price.Unwrap(), doc)
+	}
+
+	var order xmlOrder
+	doc := `<order xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><note xsi:nil="false">x</note></order>`
+	require.NoError(t, xml.Unmarshal([]byte(doc), &order))
+	assert.Equal(t, "x", order.Note.Unwrap())
+}
+
+func TestOption_UnmarshalXML_Error(t *testing.T) {
+	var order xmlOrder
+	err := xml.Unmarshal([]byte(`<order><price>cheap</price></order>`), &order)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+
+	err = xml.Unmarshal([]byte(`<order qty="many"></order>`), &order)
+	assert.ErrorIs(t, err, strconv.ErrSyntax)
+}
+
+func TestOption_XML_RoundTrip(t *testing.T) {
+	in := xmlOrder{ID: 7, SKU: Some(""), Qty: Some(2), Price: Some(1.25)}
+	out, err := xml.Marshal(in)
+	require.NoError(t, err)
+
+	var back xmlOrder
+	require.NoError(t, xml.Unmarshal(out, &back))
+	assert.True(t, in.SKU.Equal(back.SKU))
+	assert.True(t, in.Qty.Equal(back.Qty))
+	assert.True(t, in.Price.Equal(back.Price))
+	assert.True(t, back.Note.IsNone())
+}